@@ -0,0 +1,100 @@
+package dreamhost
+
+import (
+	"context"
+	"net/url"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	listAnnouncementListsCmd = "announcement_list-list_lists"
+	listSubscribersCmd       = "announcement_list-list_subscribers"
+	addSubscriberCmd         = "announcement_list-add_subscriber"
+	removeSubscriberCmd      = "announcement_list-remove_subscriber"
+
+	// subscriberNotFoundCode is returned when removing an address that is not subscribed
+	subscriberNotFoundCode = "no_such_subscriber"
+
+	subscriberIDParts = 3
+)
+
+// announcementList is a list as returned by announcement_list-list_lists
+type announcementList struct {
+	AccountID      string `json:"account_id"`
+	ListName       string `json:"listname"`
+	Domain         string `json:"domain"`
+	Name           string `json:"name"`
+	StartDate      string `json:"start_date"`
+	MaxBounces     string `json:"max_bounces"`
+	NumSubscribers string `json:"num_subscribers"`
+}
+
+// announcementSubscriber is a subscriber as returned by announcement_list-list_subscribers
+type announcementSubscriber struct {
+	Email         string `json:"email"`
+	Name          string `json:"name"`
+	Confirmed     string `json:"confirmed"`
+	SubscribeDate string `json:"subscribe_date"`
+	NumBounces    string `json:"num_bounces"`
+}
+
+// listAnnouncementLists returns the lists sorted by domain and list name,
+// optionally restricted to a domain
+func listAnnouncementLists(ctx context.Context, api *cachedDreamhostClient, domain string) ([]announcementList, error) {
+	var lists []announcementList
+	if err := api.CallCachedCommand(ctx, listAnnouncementListsCmd, nil, &lists); err != nil {
+		return nil, err
+	}
+
+	filtered := make([]announcementList, 0, len(lists))
+	for _, list := range lists {
+		if domain == "" || strings.EqualFold(list.Domain, domain) {
+			filtered = append(filtered, list)
+		}
+	}
+	sort.SliceStable(filtered, func(i, j int) bool {
+		if filtered[i].Domain != filtered[j].Domain {
+			return filtered[i].Domain < filtered[j].Domain
+		}
+		return filtered[i].ListName < filtered[j].ListName
+	})
+
+	return filtered, nil
+}
+
+// announcementListParams identifies a list to the subscriber commands
+func announcementListParams(listName, domain string) url.Values {
+	return url.Values{"listname": {listName}, "domain": {domain}}
+}
+
+// findSubscriber looks the address up in the cached subscriber listing of the list
+func findSubscriber(
+	ctx context.Context, api *cachedDreamhostClient, listName, domain, email string,
+) (*announcementSubscriber, error) {
+	var subscribers []announcementSubscriber
+	err := api.CallCachedCommand(ctx, listSubscribersCmd, announcementListParams(listName, domain), &subscribers)
+	if err != nil {
+		return nil, err
+	}
+	for i := range subscribers {
+		if strings.EqualFold(subscribers[i].Email, email) {
+			return &subscribers[i], nil
+		}
+	}
+	return nil, nil
+}
+
+func subscriberToID(listName, domain, email string) string {
+	return strings.Join([]string{listName, domain, email}, "|")
+}
+
+func idToSubscriber(id string) (listName, domain, email string, err error) {
+	parts := strings.Split(id, "|")
+	if len(parts) != subscriberIDParts || parts[0] == "" || parts[1] == "" || parts[2] == "" {
+		return "", "", "", errors.New("could not determine subscriber from ID, expected listname|domain|email")
+	}
+	return parts[0], parts[1], parts[2], nil
+}
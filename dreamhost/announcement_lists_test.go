@@ -0,0 +1,138 @@
+package dreamhost
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	testAnnouncementListsResponse = `[
+	{"account_id":"1","listname":"news","domain":"example.org","name":"News","start_date":"2020-01-02",
+	 "max_bounces":"5","num_subscribers":"12"},
+	{"account_id":"1","listname":"updates","domain":"example.com","name":"Updates","start_date":"2021-03-04",
+	 "max_bounces":"3","num_subscribers":"2"},
+	{"account_id":"1","listname":"alerts","domain":"example.com","name":"Alerts","start_date":"2022-05-06",
+	 "max_bounces":"3","num_subscribers":"0"}
+]`
+	testSubscribersResponse = `[
+	{"email":"Alice@example.net","name":"Alice","confirmed":"1","subscribe_date":"2023-01-01","num_bounces":"1"},
+	{"email":"bob@example.net","name":"","confirmed":"0","subscribe_date":"2023-02-01","num_bounces":"0"}
+]`
+)
+
+func TestListAnnouncementLists(t *testing.T) {
+	t.Parallel()
+
+	caller := newFakeCommandCaller()
+	caller.Respond(listAnnouncementListsCmd, testAnnouncementListsResponse)
+	client := newFakeCommandClient(caller)
+
+	lists, err := listAnnouncementLists(context.Background(), client, "")
+	require.NoError(t, err)
+	require.Len(t, lists, 3)
+	assert.Equal(t, []string{"alerts", "updates", "news"},
+		[]string{lists[0].ListName, lists[1].ListName, lists[2].ListName})
+
+	lists, err = listAnnouncementLists(context.Background(), client, "EXAMPLE.ORG")
+	require.NoError(t, err)
+	require.Len(t, lists, 1)
+	assert.Equal(t, "news", lists[0].ListName)
+
+	d := schema.TestResourceDataRaw(t, dataSourceAnnouncementLists().Schema, map[string]interface{}{
+		"domain": "example.com",
+	})
+	diags := dataSourceAnnouncementListsRead(context.Background(), d, client)
+	require.False(t, diags.HasError(), "%v", diags)
+	assert.Equal(t, 2, d.Get("lists.#"))
+	assert.Equal(t, "Updates", d.Get("lists.1.name"))
+	assert.Equal(t, 2, d.Get("lists.1.subscriber_count"))
+	assert.NotEmpty(t, d.Id())
+}
+
+func TestSubscriberID(t *testing.T) {
+	t.Parallel()
+
+	id := subscriberToID("news", "example.com", "alice@example.net")
+	assert.Equal(t, "news|example.com|alice@example.net", id)
+
+	listName, domain, email, err := idToSubscriber(id)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"news", "example.com", "alice@example.net"}, []string{listName, domain, email})
+
+	_, _, _, err = idToSubscriber("news|alice@example.net")
+	assert.Error(t, err)
+	_, _, _, err = idToSubscriber("news||alice@example.net")
+	assert.Error(t, err)
+}
+
+func TestResourceAnnouncementListSubscriber(t *testing.T) {
+	t.Parallel()
+
+	caller := newFakeCommandCaller()
+	caller.Respond(listSubscribersCmd+"?domain=example.com&listname=news", testSubscribersResponse)
+	caller.Respond(addSubscriberCmd, `"success"`)
+	caller.Respond(removeSubscriberCmd, `"success"`)
+	client := newFakeCommandClient(caller)
+
+	d := schema.TestResourceDataRaw(t, resourceAnnouncementListSubscriber().Schema, map[string]interface{}{})
+	d.SetId("news|example.com|alice@example.net")
+
+	diags := resourceAnnouncementListSubscriberRead(context.Background(), d, client)
+	require.False(t, diags.HasError(), "%v", diags)
+	assert.Equal(t, "Alice@example.net", d.Get("email"))
+	assert.Equal(t, "Alice", d.Get("name"))
+	assert.Equal(t, true, d.Get("confirmed"))
+	assert.Equal(t, 1, d.Get("bounce_count"))
+
+	diags = resourceAnnouncementListSubscriberDelete(context.Background(), d, client)
+	require.False(t, diags.HasError(), "%v", diags)
+	calls := caller.Calls()
+	assert.Equal(t, removeSubscriberCmd+"?domain=example.com&email=alice%40example.net&listname=news", calls[len(calls)-1])
+
+	// existing subscribers must be imported rather than added again
+	existing := schema.TestResourceDataRaw(t, resourceAnnouncementListSubscriber().Schema, map[string]interface{}{
+		"listname": "news", "domain": "example.com", "email": "bob@example.net",
+	})
+	diags = resourceAnnouncementListSubscriberCreate(context.Background(), existing, client)
+	require.True(t, diags.HasError())
+	assert.Contains(t, diags[0].Summary, `import it with ID "news|example.com|bob@example.net"`)
+
+	// subscribers removed outside of Terraform are dropped from state
+	missing := schema.TestResourceDataRaw(t, resourceAnnouncementListSubscriber().Schema, map[string]interface{}{})
+	missing.SetId("news|example.com|carol@example.net")
+	diags = resourceAnnouncementListSubscriberRead(context.Background(), missing, client)
+	require.False(t, diags.HasError(), "%v", diags)
+	assert.Empty(t, missing.Id())
+}
+
+func TestResourceAnnouncementListSubscriberEmailCase(t *testing.T) {
+	t.Parallel()
+
+	state := &terraform.InstanceState{
+		ID: "news|example.com|Foo@Example.com",
+		Attributes: map[string]string{
+			"id": "news|example.com|Foo@Example.com", "listname": "news", "domain": "example.com",
+			"email": "Foo@Example.com", "name": "Foo",
+		},
+	}
+	config := map[string]interface{}{"listname": "news", "domain": "example.com", "email": "foo@example.com"}
+
+	diff, err := resourceAnnouncementListSubscriber().Diff(
+		context.Background(), state, terraform.NewResourceConfigRaw(config), nil,
+	)
+	require.NoError(t, err)
+	assert.True(t, diff == nil || diff.Empty(), "%v", diff)
+
+	config["email"] = "bar@example.com"
+	diff, err = resourceAnnouncementListSubscriber().Diff(
+		context.Background(), state, terraform.NewResourceConfigRaw(config), nil,
+	)
+	require.NoError(t, err)
+	require.NotNil(t, diff)
+	assert.True(t, diff.RequiresNew())
+}
@@ -2,10 +2,14 @@ package dreamhost
 
 import (
 	"context"
+	"encoding/json"
+	"strconv"
+	"strings"
 	"sync"
 
 	dreamhostapi "github.com/adamantal/go-dreamhost/api"
 	"github.com/pkg/errors"
+	"golang.org/x/sync/singleflight"
 )
 
 // DNSRecordLister interface to avoid circular dependency
@@ -13,27 +17,54 @@ type DNSRecordLister interface {
 	ListDNSRecords(ctx context.Context) ([]dreamhostapi.DNSRecord, error)
 }
 
+// cache holds API responses. The mutex only guards the fields, API calls run
+// outside of it; concurrent calls for the same key share one request.
 type cache struct {
 	sync.Mutex
 
 	cachedRecords []dreamhostapi.DNSRecord
+	// recordsGeneration and commandsGeneration change on every invalidation, so
+	// responses requested before it are neither stored nor shared afterwards
+	recordsGeneration uint64
+
+	// responses of other API commands, by command and parameters; commands are
+	// also invalidated per family, the part of the command name before the dash
+	cachedCommands     map[string]json.RawMessage
+	commandsGeneration uint64
+	familyGenerations  map[string]uint64
+
+	calls singleflight.Group
 }
 
 func (c *cache) GetRecords(ctx context.Context, client DNSRecordLister) ([]dreamhostapi.DNSRecord, error) {
 	c.Lock()
-	defer c.Unlock()
+	records, generation := c.cachedRecords, c.recordsGeneration
+	c.Unlock()
+
+	if records == nil {
+		key := "records#" + strconv.FormatUint(generation, 10)
+		result, err, _ := c.calls.Do(key, func() (interface{}, error) {
+			records, err := client.ListDNSRecords(ctx)
+			if err != nil {
+				return nil, errors.Wrap(err, "failed to list DNS records")
+			}
 
-	if c.cachedRecords == nil {
-		records, err := client.ListDNSRecords(ctx)
+			c.Lock()
+			defer c.Unlock()
+			if c.recordsGeneration == generation {
+				c.cachedRecords = records
+			}
+			return records, nil
+		})
 		if err != nil {
-			return nil, errors.Wrap(err, "failed to list DNS records")
+			return nil, err
 		}
-		c.cachedRecords = records
+		records, _ = result.([]dreamhostapi.DNSRecord)
 	}
 
 	// Return a copy to prevent external modification
-	result := make([]dreamhostapi.DNSRecord, len(c.cachedRecords))
-	copy(result, c.cachedRecords)
+	result := make([]dreamhostapi.DNSRecord, len(records))
+	copy(result, records)
 	return result, nil
 }
 
@@ -41,4 +72,76 @@ func (c *cache) Invalidate() {
 	c.Lock()
 	defer c.Unlock()
 	c.cachedRecords = nil
+	c.recordsGeneration++
+}
+
+// GetCommand returns the cached response for key, calling fetch when it is missing
+func (c *cache) GetCommand(
+	ctx context.Context, key string, fetch func(ctx context.Context) (json.RawMessage, error),
+) (json.RawMessage, error) {
+	family := commandFamily(key)
+
+	c.Lock()
+	data, ok := c.cachedCommands[key]
+	generation, familyGeneration := c.commandsGeneration, c.familyGenerations[family]
+	c.Unlock()
+
+	if ok {
+		return data, nil
+	}
+
+	callKey := "command#" + strconv.FormatUint(generation, 10) + "." + strconv.FormatUint(familyGeneration, 10) + "#" + key
+	result, err, _ := c.calls.Do(callKey, func() (interface{}, error) {
+		data, err := fetch(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		c.Lock()
+		defer c.Unlock()
+		if c.commandsGeneration == generation && c.familyGenerations[family] == familyGeneration {
+			if c.cachedCommands == nil {
+				c.cachedCommands = make(map[string]json.RawMessage)
+			}
+			c.cachedCommands[key] = data
+		}
+		return data, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	data, _ = result.(json.RawMessage)
+	return data, nil
+}
+
+// InvalidateCommands drops the cached responses of non-DNS commands
+func (c *cache) InvalidateCommands() {
+	c.Lock()
+	defer c.Unlock()
+	c.cachedCommands = nil
+	c.commandsGeneration++
+}
+
+// InvalidateCommandFamily drops the cached responses of the commands of a family,
+// e.g. announcement_list for announcement_list-list_subscribers
+func (c *cache) InvalidateCommandFamily(family string) {
+	c.Lock()
+	defer c.Unlock()
+	for key := range c.cachedCommands {
+		if commandFamily(key) == family {
+			delete(c.cachedCommands, key)
+		}
+	}
+	if c.familyGenerations == nil {
+		c.familyGenerations = make(map[string]uint64)
+	}
+	c.familyGenerations[family]++
+}
+
+// commandFamily returns the family of a command or cache key, the part before the dash
+func commandFamily(command string) string {
+	if i := strings.Index(command, "-"); i >= 0 {
+		return command[:i]
+	}
+	return command
 }
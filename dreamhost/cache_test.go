@@ -2,8 +2,10 @@ package dreamhost
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -300,6 +302,137 @@ func TestCache_ReturnsCopy(t *testing.T) {
 	assert.NotEqual(t, "modified", records2[0].Value)
 }
 
+func TestCache_GetCommand(t *testing.T) {
+	t.Parallel()
+
+	t.Run("slow_command_does_not_block_other_calls", func(t *testing.T) {
+		t.Parallel()
+
+		c := &cache{}
+		mockClient := NewMockDreamhostClient()
+		mockClient.SetRecords([]dreamhostapi.DNSRecord{{Record: "example.com", Type: dreamhostapi.ARecordType}})
+
+		release := make(chan struct{})
+		started := make(chan struct{})
+		go func() {
+			_, _ = c.GetCommand(context.Background(), "slow", func(ctx context.Context) (json.RawMessage, error) {
+				close(started)
+				<-release
+				return json.RawMessage(`[]`), nil
+			})
+		}()
+		<-started
+		defer close(release)
+
+		done := make(chan struct{})
+		go func() {
+			defer close(done)
+			records, err := c.GetRecords(context.Background(), mockClient)
+			assert.NoError(t, err)
+			assert.Len(t, records, 1)
+			data, err := c.GetCommand(context.Background(), "other", func(ctx context.Context) (json.RawMessage, error) {
+				return json.RawMessage(`{}`), nil
+			})
+			assert.NoError(t, err)
+			assert.Equal(t, json.RawMessage(`{}`), data)
+		}()
+
+		select {
+		case <-done:
+		case <-time.After(5 * time.Second):
+			t.Fatal("calls were blocked by a pending command")
+		}
+	})
+
+	t.Run("concurrent_calls_share_one_fetch", func(t *testing.T) {
+		t.Parallel()
+
+		c := &cache{}
+		var fetches int32
+		release := make(chan struct{})
+		fetch := func(ctx context.Context) (json.RawMessage, error) {
+			atomic.AddInt32(&fetches, 1)
+			<-release
+			return json.RawMessage(`["shared"]`), nil
+		}
+
+		var wg sync.WaitGroup
+		for i := 0; i < 5; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				data, err := c.GetCommand(context.Background(), "key", fetch)
+				assert.NoError(t, err)
+				assert.Equal(t, json.RawMessage(`["shared"]`), data)
+			}()
+		}
+		time.Sleep(50 * time.Millisecond)
+		close(release)
+		wg.Wait()
+
+		assert.Equal(t, int32(1), atomic.LoadInt32(&fetches))
+	})
+
+	t.Run("invalidation_during_fetch_is_not_undone", func(t *testing.T) {
+		t.Parallel()
+
+		c := &cache{}
+		_, err := c.GetCommand(context.Background(), "key", func(ctx context.Context) (json.RawMessage, error) {
+			c.InvalidateCommands()
+			return json.RawMessage(`"stale"`), nil
+		})
+		require.NoError(t, err)
+
+		data, err := c.GetCommand(context.Background(), "key", func(ctx context.Context) (json.RawMessage, error) {
+			return json.RawMessage(`"fresh"`), nil
+		})
+		require.NoError(t, err)
+		assert.Equal(t, json.RawMessage(`"fresh"`), data)
+	})
+
+	t.Run("family_invalidation_keeps_other_families", func(t *testing.T) {
+		t.Parallel()
+
+		c := &cache{}
+		respond := func(value string) func(ctx context.Context) (json.RawMessage, error) {
+			return func(ctx context.Context) (json.RawMessage, error) {
+				return json.RawMessage(value), nil
+			}
+		}
+		_, err := c.GetCommand(context.Background(), "announcement_list-list_subscribers?listname=news", respond(`"old"`))
+		require.NoError(t, err)
+		_, err = c.GetCommand(context.Background(), "mysql-list_users", respond(`"users"`))
+		require.NoError(t, err)
+
+		c.InvalidateCommandFamily(commandFamily("announcement_list-add_subscriber"))
+
+		data, err := c.GetCommand(context.Background(), "announcement_list-list_subscribers?listname=news", respond(`"new"`))
+		require.NoError(t, err)
+		assert.Equal(t, json.RawMessage(`"new"`), data)
+
+		data, err = c.GetCommand(context.Background(), "mysql-list_users", respond(`"refetched"`))
+		require.NoError(t, err)
+		assert.Equal(t, json.RawMessage(`"users"`), data)
+	})
+
+	t.Run("family_invalidation_during_fetch_is_not_undone", func(t *testing.T) {
+		t.Parallel()
+
+		c := &cache{}
+		_, err := c.GetCommand(context.Background(), "mysql-list_users", func(ctx context.Context) (json.RawMessage, error) {
+			c.InvalidateCommandFamily("mysql")
+			return json.RawMessage(`"stale"`), nil
+		})
+		require.NoError(t, err)
+
+		data, err := c.GetCommand(context.Background(), "mysql-list_users", func(ctx context.Context) (json.RawMessage, error) {
+			return json.RawMessage(`"fresh"`), nil
+		})
+		require.NoError(t, err)
+		assert.Equal(t, json.RawMessage(`"fresh"`), data)
+	})
+}
+
 func BenchmarkCache_GetRecords(b *testing.B) {
 	mockClient := NewMockDreamhostClient()
 	testRecords := make([]dreamhostapi.DNSRecord, 1000)
@@ -2,17 +2,33 @@ package dreamhost
 
 import (
 	"context"
+	"encoding/json"
+	"net/url"
+	"sync"
 
 	dreamhostapi "github.com/adamantal/go-dreamhost/api"
 	"github.com/pkg/errors"
 )
 
+// dreamhostAPI is the subset of the go-dreamhost client used by the provider
+type dreamhostAPI interface {
+	AddDNSRecord(ctx context.Context, recordInput dreamhostapi.DNSRecordInput) error
+	ListDNSRecords(ctx context.Context) ([]dreamhostapi.DNSRecord, error)
+	RemoveDNSRecord(ctx context.Context, recordInput dreamhostapi.DNSRecordInput) error
+}
+
 type cachedDreamhostClient struct {
-	client *dreamhostapi.Client
-	cache  cache
+	client   dreamhostAPI
+	commands commandCaller
+	limiter  *rateLimiter
+	cache    cache
+
+	// privateServerCreates serializes private server creation, which identifies
+	// the new server by comparing the server listing before and after the request
+	privateServerCreates sync.Mutex
 }
 
-func newDreamhostClient(client *dreamhostapi.Client) *cachedDreamhostClient {
+func newDreamhostClient(client dreamhostAPI) *cachedDreamhostClient {
 	return &cachedDreamhostClient{
 		client: client,
 	}
@@ -67,3 +83,73 @@ func (c *cachedDreamhostClient) RemoveDNSRecord(ctx context.Context, recordInput
 	}
 	return err
 }
+
+// ListCachedDNSRecords returns all DNS records, served from the cache when it is populated
+func (c *cachedDreamhostClient) ListCachedDNSRecords(ctx context.Context) ([]dreamhostapi.DNSRecord, error) {
+	return c.cache.GetRecords(ctx, c)
+}
+
+// CallCommand runs a DreamHost API command that changes state and decodes its
+// data into result, which may be nil. The cached responses of its command family are dropped.
+func (c *cachedDreamhostClient) CallCommand(
+	ctx context.Context, command string, params url.Values, result interface{},
+) error {
+	if c.commands == nil {
+		return errors.New("internal error: API command client is not configured")
+	}
+
+	data, err := c.callCommand(ctx, command, params)
+	if err != nil {
+		return err
+	}
+	// a change only affects the listings of its own family, e.g. adding a
+	// subscriber leaves the cached MySQL and user listings valid
+	c.cache.InvalidateCommandFamily(commandFamily(command))
+
+	return decodeCommandData(command, data, result)
+}
+
+// CallCachedCommand runs a read-only DreamHost API command, serving repeated
+// calls with the same parameters from the cache
+func (c *cachedDreamhostClient) CallCachedCommand(
+	ctx context.Context, command string, params url.Values, result interface{},
+) error {
+	if c.commands == nil {
+		return errors.New("internal error: API command client is not configured")
+	}
+
+	data, err := c.cache.GetCommand(ctx, command+"?"+params.Encode(), func(ctx context.Context) (json.RawMessage, error) {
+		return c.callCommand(ctx, command, params)
+	})
+	if err != nil {
+		return err
+	}
+
+	return decodeCommandData(command, data, result)
+}
+
+// callCommand runs a command under the rate limiter, retrying retryable errors
+func (c *cachedDreamhostClient) callCommand(
+	ctx context.Context, command string, params url.Values,
+) (json.RawMessage, error) {
+	var data json.RawMessage
+	err := retryOnError(ctx, func() error {
+		if err := c.limiter.Wait(ctx); err != nil {
+			return err
+		}
+		var err error
+		data, err = c.commands.Call(ctx, command, params)
+		return err
+	})
+	return data, err
+}
+
+func decodeCommandData(command string, data json.RawMessage, result interface{}) error {
+	if result == nil {
+		return nil
+	}
+	if err := json.Unmarshal(data, result); err != nil {
+		return errors.Wrapf(err, "failed to unmarshal %s response", command)
+	}
+	return nil
+}
@@ -0,0 +1,128 @@
+package dreamhost
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	dreamhostAPIURL = "https://api.dreamhost.com/"
+
+	apiResultSuccess = "success"
+)
+
+// commandCaller runs an arbitrary DreamHost API command; go-dreamhost only
+// implements the DNS commands
+type commandCaller interface {
+	Call(ctx context.Context, command string, params url.Values) (json.RawMessage, error)
+}
+
+// commandClient calls the DreamHost API over HTTP
+type commandClient struct {
+	apiKey     string
+	baseURL    string
+	httpClient *http.Client
+}
+
+func newCommandClient(apiKey string, httpClient *http.Client) *commandClient {
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+	return &commandClient{
+		apiKey:     apiKey,
+		baseURL:    dreamhostAPIURL,
+		httpClient: httpClient,
+	}
+}
+
+// commandResponse is the envelope of every DreamHost API response
+type commandResponse struct {
+	Result string          `json:"result"`
+	Data   json.RawMessage `json:"data"`
+	Reason string          `json:"reason"`
+}
+
+// Call runs the command and returns the data of a successful response. The
+// parameters are sent as a form body, so the API key and passwords never end
+// up in the request URL, which transport errors include.
+func (c *commandClient) Call(ctx context.Context, command string, params url.Values) (json.RawMessage, error) {
+	form := url.Values{}
+	for key, values := range params {
+		form[key] = values
+	}
+	form.Set("key", c.apiKey)
+	form.Set("cmd", command)
+	form.Set("format", "json")
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.baseURL, strings.NewReader(form.Encode()))
+	if err != nil {
+		return nil, errors.Wrap(err, "could not create request")
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to send %s request", command)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read body of response")
+	}
+
+	var envelope commandResponse
+	if err := json.Unmarshal(body, &envelope); err != nil {
+		return nil, errors.Wrapf(err, "failed to unmarshal %s response (HTTP %d)", command, resp.StatusCode)
+	}
+	if envelope.Result != apiResultSuccess {
+		return nil, newCommandError(command, envelope)
+	}
+
+	return envelope.Data, nil
+}
+
+// commandError is an unsuccessful API response
+type commandError struct {
+	Command string
+	// Code is the error code DreamHost returns in data, e.g. no_such_domain
+	Code   string
+	Reason string
+}
+
+func newCommandError(command string, envelope commandResponse) *commandError {
+	var code string
+	if err := json.Unmarshal(envelope.Data, &code); err != nil {
+		code = string(envelope.Data)
+	}
+	return &commandError{Command: command, Code: code, Reason: envelope.Reason}
+}
+
+func (e *commandError) Error() string {
+	detail := e.Code
+	if e.Reason != "" {
+		detail += " (" + e.Reason + ")"
+	}
+	return fmt.Sprintf("%s failed - response: %s", e.Command, detail)
+}
+
+// isCommandError reports whether the error, or its cause, is an API error with one of the codes
+func isCommandError(err error, codes ...string) bool {
+	var cmdErr *commandError
+	if !errors.As(err, &cmdErr) {
+		return false
+	}
+	for _, code := range codes {
+		if cmdErr.Code == code {
+			return true
+		}
+	}
+	return false
+}
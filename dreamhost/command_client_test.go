@@ -0,0 +1,227 @@
+package dreamhost
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeCommandCaller serves canned API command responses and records the calls
+type fakeCommandCaller struct {
+	mu        sync.Mutex
+	responses map[string]string
+	errors    map[string]error
+	calls     []string
+}
+
+func newFakeCommandCaller() *fakeCommandCaller {
+	return &fakeCommandCaller{responses: map[string]string{}, errors: map[string]error{}}
+}
+
+// newFakeCommandClient returns a provider client whose API commands are served by the fake
+func newFakeCommandClient(caller *fakeCommandCaller) *cachedDreamhostClient {
+	client := newDreamhostClient(NewMockDreamhostClient())
+	client.commands = caller
+	return client
+}
+
+// Respond sets the data returned for a command, or for a command with specific
+// parameters when given as "command?encoded-params"
+func (f *fakeCommandCaller) Respond(command, data string) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.responses[command] = data
+}
+
+// Fail makes a command, or a command with specific parameters, return an error
+func (f *fakeCommandCaller) Fail(command string, err error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.errors[command] = err
+}
+
+// Calls returns the commands called so far with their encoded parameters
+func (f *fakeCommandCaller) Calls() []string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return append([]string{}, f.calls...)
+}
+
+func (f *fakeCommandCaller) Call(_ context.Context, command string, params url.Values) (json.RawMessage, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	call := command + "?" + params.Encode()
+	f.calls = append(f.calls, call)
+	if err := f.errors[call]; err != nil {
+		return nil, err
+	}
+	if err := f.errors[command]; err != nil {
+		return nil, err
+	}
+	data, ok := f.responses[call]
+	if !ok {
+		data, ok = f.responses[command]
+	}
+	if !ok {
+		return nil, fmt.Errorf("%s failed - response: no canned response", command)
+	}
+	return json.RawMessage(data), nil
+}
+
+func TestCommandClientCall(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// parameters travel in the body, never in the URL
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.Empty(t, r.URL.RawQuery)
+		require.NoError(t, r.ParseForm())
+		query := r.PostForm
+		assert.Equal(t, "test-key", query.Get("key"))
+		assert.Equal(t, "json", query.Get("format"))
+
+		switch query.Get("cmd") {
+		case "domain-list_domains":
+			fmt.Fprint(w, `{"result":"success","data":[{"domain":"example.com"}]}`)
+		case "user-add_user":
+			assert.Equal(t, "alice", query.Get("username"))
+			fmt.Fprint(w, `{"result":"error","data":"username_taken","reason":"pick another one"}`)
+		default:
+			fmt.Fprint(w, `not json`)
+		}
+	}))
+	defer server.Close()
+
+	client := newCommandClient("test-key", server.Client())
+	client.baseURL = server.URL + "/"
+
+	data, err := client.Call(context.Background(), "domain-list_domains", nil)
+	require.NoError(t, err)
+	assert.JSONEq(t, `[{"domain":"example.com"}]`, string(data))
+
+	_, err = client.Call(context.Background(), "user-add_user", url.Values{"username": {"alice"}})
+	assert.EqualError(t, err, "user-add_user failed - response: username_taken (pick another one)")
+	assert.True(t, isCommandError(errors.Wrap(err, "failed to add user"), "no_such_user", "username_taken"))
+	assert.False(t, isCommandError(err, "no_such_user"))
+
+	_, err = client.Call(context.Background(), "unknown", nil)
+	assert.ErrorContains(t, err, "failed to unmarshal unknown response")
+}
+
+// roundTripperFunc is an http.RoundTripper backed by a function
+type roundTripperFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(r *http.Request) (*http.Response, error) {
+	return f(r)
+}
+
+func TestCommandClientCallKeepsSecretsOutOfErrors(t *testing.T) {
+	t.Parallel()
+
+	client := newCommandClient("secret-key", &http.Client{
+		Transport: roundTripperFunc(func(*http.Request) (*http.Response, error) {
+			return nil, fmt.Errorf("connection refused")
+		}),
+	})
+
+	_, err := client.Call(context.Background(), addMySQLUserCmd, url.Values{"password": {"hunter2"}})
+	require.Error(t, err)
+	assert.ErrorContains(t, err, "connection refused")
+	assert.NotContains(t, err.Error(), "password=")
+	assert.NotContains(t, err.Error(), "hunter2")
+	assert.NotContains(t, err.Error(), "secret-key")
+}
+
+func TestCachedDreamhostClient_CallCachedCommand(t *testing.T) {
+	t.Parallel()
+
+	caller := newFakeCommandCaller()
+	caller.Respond("domain-list_domains", `[{"domain":"example.com"}]`)
+	caller.Respond("mail-add_filter", `"success"`)
+	client := newFakeCommandClient(caller)
+
+	var domains []hostedDomain
+	require.NoError(t, client.CallCachedCommand(context.Background(), "domain-list_domains", nil, &domains))
+	require.NoError(t, client.CallCachedCommand(context.Background(), "domain-list_domains", nil, &domains))
+	assert.Equal(t, []hostedDomain{{Domain: "example.com"}}, domains)
+	assert.Len(t, caller.Calls(), 1)
+
+	// state changing commands only drop the cached responses of their own family
+	require.NoError(t, client.CallCommand(context.Background(), "mail-add_filter", nil, nil))
+	require.NoError(t, client.CallCachedCommand(context.Background(), "domain-list_domains", nil, &domains))
+	assert.Len(t, caller.Calls(), 2)
+
+	caller.Respond("domain-remove_domain", `"success"`)
+	require.NoError(t, client.CallCommand(context.Background(), "domain-remove_domain", nil, nil))
+	require.NoError(t, client.CallCachedCommand(context.Background(), "domain-list_domains", nil, &domains))
+	assert.Len(t, caller.Calls(), 4)
+
+	caller.Fail("domain-list_domains", fmt.Errorf("domain-list_domains failed - response: internal_error"))
+	err := client.CallCachedCommand(context.Background(), "domain-list_domains", url.Values{"x": {"1"}}, &domains)
+	assert.Error(t, err)
+
+	err = newDreamhostClient(NewMockDreamhostClient()).CallCachedCommand(context.Background(), "x", nil, nil)
+	assert.ErrorContains(t, err, "not configured")
+}
+
+func TestDeleteOfRemovedObjects(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		resource *schema.Resource
+		delete   func(context.Context, *schema.ResourceData, interface{}) diag.Diagnostics
+		id       string
+		command  string
+		code     string
+	}{
+		{
+			"announcement_list_subscriber", resourceAnnouncementListSubscriber(), resourceAnnouncementListSubscriberDelete,
+			"news|example.com|gone@example.net", removeSubscriberCmd, subscriberNotFoundCode,
+		},
+		{
+			"mysql_hostname", resourceMySQLHostname(), resourceMySQLHostnameDelete,
+			"gone.example.com", removeMySQLHostnameCmd, mysqlHostnameNotFoundCode,
+		},
+		{"mysql_user", resourceMySQLUser(), resourceMySQLUserDelete, "shop|gone", removeMySQLUserCmd, mysqlUserNotFoundCode},
+		{
+			"private_server", resourcePrivateServer(), resourcePrivateServerDelete,
+			"ps10009", removePrivateServerCmd, privateServerNotFoundCode,
+		},
+		{"user", resourceUser(), resourceUserDelete, "gone", removeUserCmd, userNotFoundCode},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			d := schema.TestResourceDataRaw(t, tt.resource.Schema, map[string]interface{}{})
+			d.SetId(tt.id)
+
+			// removing an object that is already gone succeeds
+			caller := newFakeCommandCaller()
+			caller.Fail(tt.command, &commandError{Command: tt.command, Code: tt.code})
+			diags := tt.delete(context.Background(), d, newFakeCommandClient(caller))
+			assert.False(t, diags.HasError(), "%v", diags)
+
+			// other errors still fail the deletion
+			caller = newFakeCommandCaller()
+			caller.Fail(tt.command, &commandError{Command: tt.command, Code: "internal_error"})
+			diags = tt.delete(context.Background(), d, newFakeCommandClient(caller))
+			require.True(t, diags.HasError())
+			assert.Contains(t, diags[0].Summary, "internal_error")
+		})
+	}
+}
@@ -0,0 +1,107 @@
+package dreamhost
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourceAnnouncementLists() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceAnnouncementListsRead,
+		Schema: map[string]*schema.Schema{
+			"domain": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Only return lists of this domain",
+			},
+			// Computed fields
+			"lists": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "List of announcement lists, sorted by domain and list name",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"listname": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The list name, the local part of the list address",
+						},
+						"domain": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The domain of the list",
+						},
+						"name": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The display name of the list",
+						},
+						"start_date": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The date the list was created",
+						},
+						"max_bounces": {
+							Type:        schema.TypeInt,
+							Computed:    true,
+							Description: "Bounces after which a subscriber is removed",
+						},
+						"subscriber_count": {
+							Type:        schema.TypeInt,
+							Computed:    true,
+							Description: "The number of subscribers",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceAnnouncementListsRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	api, ok := meta.(*cachedDreamhostClient)
+	if !ok {
+		return diag.Errorf("internal error: failed to retrieve dreamhost API client")
+	}
+
+	domain, _ := d.Get("domain").(string)
+
+	lists, err := listAnnouncementLists(ctx, api, domain)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	listData := make([]map[string]interface{}, 0, len(lists))
+	for _, list := range lists {
+		maxBounces, _ := strconv.Atoi(list.MaxBounces)
+		subscribers, _ := strconv.Atoi(list.NumSubscribers)
+		listData = append(listData, map[string]interface{}{
+			"listname":         list.ListName,
+			"domain":           list.Domain,
+			"name":             list.Name,
+			"start_date":       list.StartDate,
+			"max_bounces":      maxBounces,
+			"subscriber_count": subscribers,
+		})
+	}
+
+	if err := d.Set("lists", listData); err != nil {
+		return diag.Errorf("failed to set lists: %v", err)
+	}
+
+	id, err := hashID(map[string]interface{}{
+		"domain": domain,
+		"lists":  listData,
+	})
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	d.SetId(id)
+
+	return diags
+}
@@ -2,7 +2,6 @@ package dreamhost
 
 import (
 	"context"
-	"fmt"
 
 	dreamhostapi "github.com/adamantal/go-dreamhost/api"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
@@ -29,7 +28,59 @@ func dataSourceDNSRecord() *schema.Resource {
 				Computed:    true,
 				Description: "The DNS record value (optional for lookup, will be populated from the found record)",
 			},
+			"allow_multiple": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Return all values of the record instead of failing when there are several",
+			},
 			// Computed fields
+			"values": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "All matching values, sorted by priority for MX and SRV records and lexically otherwise",
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+			"fqdn": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The fully qualified record name, without trailing dot",
+			},
+			"relative_name": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The record name relative to its zone, `@` for the apex",
+			},
+			"mx_priority": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "The priority of an MX record",
+			},
+			"mx_exchange": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The mail server of an MX record, without trailing dot",
+			},
+			"srv_priority": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "The priority of an SRV record",
+			},
+			"srv_weight": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "The weight of an SRV record",
+			},
+			"srv_port": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "The port of an SRV record",
+			},
+			"srv_target": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The target host of an SRV record, without trailing dot",
+			},
 			"id": {
 				Type:        schema.TypeString,
 				Computed:    true,
@@ -77,31 +128,44 @@ func dataSourceDNSRecordRead(ctx context.Context, d *schema.ResourceData, m inte
 		return diag.FromErr(err)
 	}
 
-	// Find matching record
-	var foundRecord *dreamhostapi.DNSRecord
+	// Find matching records
+	var matches []dreamhostapi.DNSRecord
 	for _, record := range records {
 		if record.Record == recordName && string(record.Type) == recordType {
 			// If value is specified, must match exactly
 			if hasValue && record.Value != recordValue.(string) {
 				continue
 			}
-			// If we already found a record and no value filter, this is ambiguous
-			if foundRecord != nil && !hasValue {
-				return diag.Errorf("multiple DNS records found for %s (type: %s). Please specify 'value' to disambiguate", recordName, recordType)
-			}
-			foundRecord = &record
-			if hasValue {
-				break // Exact match found
-			}
+			matches = append(matches, record)
 		}
 	}
 
-	if foundRecord == nil {
+	if len(matches) == 0 {
 		if hasValue {
 			return diag.Errorf("DNS record not found: %s (type: %s, value: %s)", recordName, recordType, recordValue)
 		}
 		return diag.Errorf("DNS record not found: %s (type: %s)", recordName, recordType)
 	}
+	allowMultiple, _ := d.Get("allow_multiple").(bool)
+	if len(matches) > 1 && !allowMultiple {
+		return diag.Errorf("multiple DNS records found for %s (type: %s). Please specify 'value' to disambiguate "+
+			"or set 'allow_multiple' to return all values", recordName, recordType)
+	}
+
+	values := make([]string, 0, len(matches))
+	for _, record := range matches {
+		values = append(values, record.Value)
+	}
+	sortRecordValues(recordType, values)
+
+	// the scalar attributes describe the first (preferred) value
+	foundRecord := &matches[0]
+	for i := range matches {
+		if matches[i].Value == values[0] {
+			foundRecord = &matches[i]
+			break
+		}
+	}
 
 	// Set all fields
 	id := recordInputToID(dreamhostapi.DNSRecordInput{
@@ -109,6 +173,12 @@ func dataSourceDNSRecordRead(ctx context.Context, d *schema.ResourceData, m inte
 		Type:   foundRecord.Type,
 		Value:  foundRecord.Value,
 	})
+	if len(matches) > 1 {
+		id, err = hashID(map[string]interface{}{"record": recordName, "type": recordType, "values": values})
+		if err != nil {
+			return diag.FromErr(err)
+		}
+	}
 	d.SetId(id)
 
 	if err := d.Set("id", id); err != nil {
@@ -135,7 +205,48 @@ func dataSourceDNSRecordRead(ctx context.Context, d *schema.ResourceData, m inte
 	if err := d.Set("editable", string(foundRecord.Editable)); err != nil {
 		return diag.Errorf("failed to set editable: %v", err)
 	}
+	if err := d.Set("values", values); err != nil {
+		return diag.Errorf("failed to set values: %v", err)
+	}
+
+	for key, value := range parsedRecordFields(*foundRecord) {
+		if err := d.Set(key, value); err != nil {
+			return diag.Errorf("failed to set %s: %v", key, err)
+		}
+	}
 
 	return diags
 }
 
+// parsedRecordFields splits the record name and MX or SRV values into their
+// parts; the fields of other record types are left empty
+func parsedRecordFields(record dreamhostapi.DNSRecord) map[string]interface{} {
+	fqdn := dnsRecordFQDN(record.Record)
+	fields := map[string]interface{}{
+		"fqdn":          fqdn,
+		"relative_name": relativeRecordName(fqdn, dnsRecordFQDN(record.Zone)),
+		"mx_priority":   0,
+		"mx_exchange":   "",
+		"srv_priority":  0,
+		"srv_weight":    0,
+		"srv_port":      0,
+		"srv_target":    "",
+	}
+
+	switch record.Type {
+	case "MX":
+		if mx, err := parseMXValue(record.Value); err == nil {
+			fields["mx_priority"] = mx.Priority
+			fields["mx_exchange"] = mx.Exchange
+		}
+	case dreamhostapi.SRVRecordType:
+		if srv, err := parseSRVValue(record.Value); err == nil {
+			fields["srv_priority"] = srv.Priority
+			fields["srv_weight"] = srv.Weight
+			fields["srv_port"] = srv.Port
+			fields["srv_target"] = srv.Target
+		}
+	}
+
+	return fields
+}
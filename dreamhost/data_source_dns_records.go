@@ -2,12 +2,29 @@ package dreamhost
 
 import (
 	"context"
-	"strconv"
-	"time"
+	"encoding/json"
+	"fmt"
+	"path"
+	"regexp"
+	"sort"
+	"strings"
 
 	dreamhostapi "github.com/adamantal/go-dreamhost/api"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/pkg/errors"
+)
+
+const (
+	matchSubstring = "substring"
+	matchRegex     = "regex"
+	matchGlob      = "glob"
+
+	sortByRecord = "record"
+	sortByType   = "type"
+	sortByValue  = "value"
+	sortByZone   = "zone"
 )
 
 func dataSourceDNSRecords() *schema.Resource {
@@ -17,32 +34,75 @@ func dataSourceDNSRecords() *schema.Resource {
 			"filter": {
 				Type:        schema.TypeList,
 				Optional:    true,
-				Description: "Filter criteria for DNS records",
+				Description: "Filter criteria for DNS records, a record is returned if it matches any filter block",
+				Elem: &schema.Resource{
+					Schema: dnsRecordFilterSchema(),
+				},
+			},
+			"exclude": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Description: "Exclusion criteria for DNS records, a record matching any exclude block is not returned",
+				Elem: &schema.Resource{
+					Schema: dnsRecordFilterSchema(),
+				},
+			},
+			"types": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Description: "Only return records of these types",
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+			"sort_by": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      sortByRecord,
+				ValidateFunc: validation.StringInSlice([]string{sortByRecord, sortByType, sortByValue, sortByZone}, false),
+				Description:  "Order of the returned records: record (default), type, value or zone",
+			},
+			"ids": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "IDs of the returned records, in the order of `records`",
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+			"by_name": {
+				Type:        schema.TypeMap,
+				Computed:    true,
+				Description: "Sorted record values grouped by `<fqdn>/<type>`, each encoded as a JSON list",
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+			"groups": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "Record values grouped by name and type, sorted by name and type",
 				Elem: &schema.Resource{
 					Schema: map[string]*schema.Schema{
-						"record": {
+						"name": {
 							Type:        schema.TypeString,
-							Optional:    true,
-							Description: "Filter by record name (supports partial match)",
+							Computed:    true,
+							Description: "The fully qualified record name, lowercase and without the trailing dot",
 						},
 						"type": {
 							Type:        schema.TypeString,
-							Optional:    true,
-							Description: "Filter by record type (A, AAAA, CNAME, MX, NS, PTR, TXT, SRV, NAPTR)",
-						},
-						"value": {
-							Type:        schema.TypeString,
-							Optional:    true,
-							Description: "Filter by record value (supports partial match)",
+							Computed:    true,
+							Description: "The record type",
 						},
-						"zone": {
-							Type:        schema.TypeString,
-							Optional:    true,
-							Description: "Filter by zone",
+						"values": {
+							Type:        schema.TypeList,
+							Computed:    true,
+							Description: "Sorted values of the records with this name and type",
+							Elem:        &schema.Schema{Type: schema.TypeString},
 						},
 					},
 				},
 			},
+			"zones": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "Sorted list of the zones of the returned records",
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
 			"records": {
 				Type:        schema.TypeList,
 				Computed:    true,
@@ -96,6 +156,39 @@ func dataSourceDNSRecords() *schema.Resource {
 	}
 }
 
+// dnsRecordFilterSchema returns the criteria shared by the filter and exclude blocks
+func dnsRecordFilterSchema() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"record": {
+			Type:        schema.TypeString,
+			Optional:    true,
+			Description: "Filter by record name (partial, regex or glob match depending on `match`)",
+		},
+		"type": {
+			Type:        schema.TypeString,
+			Optional:    true,
+			Description: "Filter by record type (A, AAAA, CNAME, MX, NS, PTR, TXT, SRV, NAPTR)",
+		},
+		"value": {
+			Type:        schema.TypeString,
+			Optional:    true,
+			Description: "Filter by record value (partial, regex or glob match depending on `match`)",
+		},
+		"zone": {
+			Type:        schema.TypeString,
+			Optional:    true,
+			Description: "Filter by zone",
+		},
+		"match": {
+			Type:         schema.TypeString,
+			Optional:     true,
+			Default:      matchSubstring,
+			ValidateFunc: validation.StringInSlice([]string{matchSubstring, matchRegex, matchGlob}, false),
+			Description:  "How `record` and `value` are matched: substring (default), regex or glob",
+		},
+	}
+}
+
 func dataSourceDNSRecordsRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	api, ok := m.(*cachedDreamhostClient)
 	if !ok {
@@ -104,26 +197,35 @@ func dataSourceDNSRecordsRead(ctx context.Context, d *schema.ResourceData, m int
 
 	var diags diag.Diagnostics
 
+	// Compile filters first so malformed patterns fail before calling the API
+	filters, err := expandDNSRecordFilters(d.Get("filter"), "filter")
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	excludes, err := expandDNSRecordFilters(d.Get("exclude"), "exclude")
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	types := expandStringList(d.Get("types").([]interface{}))
+
 	// Get all DNS records
 	records, err := api.ListDNSRecords(ctx)
 	if err != nil {
 		return diag.FromErr(err)
 	}
 
-	// Apply filters if provided
-	if v, ok := d.GetOk("filter"); ok {
-		filters := v.([]interface{})
-		if len(filters) > 0 && filters[0] != nil {
-			filterMap := filters[0].(map[string]interface{})
-			records = filterDNSRecords(records, filterMap)
-		}
-	}
+	records = filterDNSRecords(records, filters, excludes, types)
+	sortBy, _ := d.Get("sort_by").(string)
+	sortDNSRecords(records, sortBy)
 
 	// Convert records to list of maps
 	recordList := make([]map[string]interface{}, 0, len(records))
+	ids := make([]string, 0, len(records))
 	for _, record := range records {
+		id := recordInputToID(dreamhostapi.DNSRecordInput{Record: record.Record, Type: record.Type, Value: record.Value})
+		ids = append(ids, id)
 		recordMap := map[string]interface{}{
-			"id":         recordInputToID(dreamhostapi.DNSRecordInput{Record: record.Record, Type: record.Type, Value: record.Value}),
+			"id":         id,
 			"record":     record.Record,
 			"type":       string(record.Type),
 			"value":      record.Value,
@@ -135,56 +237,170 @@ func dataSourceDNSRecordsRead(ctx context.Context, d *schema.ResourceData, m int
 		recordList = append(recordList, recordMap)
 	}
 
-	if err := d.Set("records", recordList); err != nil {
-		return diag.Errorf("failed to set records: %v", err)
+	groups := groupDNSRecordValues(records)
+	byName, err := dnsRecordValuesByName(groups)
+	if err != nil {
+		return diag.FromErr(err)
 	}
 
-	// Use current timestamp as ID to ensure the data source is always refreshed
-	d.SetId(strconv.FormatInt(time.Now().Unix(), 10))
+	fields := map[string]interface{}{
+		"records": recordList,
+		"ids":     ids,
+		"by_name": byName,
+		"groups":  groups,
+		"zones":   dnsRecordZones(records),
+	}
+	for key, value := range fields {
+		if err := d.Set(key, value); err != nil {
+			return diag.Errorf("failed to set %s: %v", key, err)
+		}
+	}
+
+	// The ID only changes when the query or its result does, so dependent for_each
+	// expressions do not churn between plans
+	id, err := hashID(map[string]interface{}{
+		"filter":  d.Get("filter"),
+		"exclude": d.Get("exclude"),
+		"types":   types,
+		"sort_by": sortBy,
+		"ids":     ids,
+	})
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	d.SetId(id)
 
 	return diags
 }
 
-func filterDNSRecords(records []dreamhostapi.DNSRecord, filters map[string]interface{}) []dreamhostapi.DNSRecord {
+// dnsRecordFilter is a compiled filter or exclude block
+type dnsRecordFilter struct {
+	record string
+	typ    string
+	value  string
+	zone   string
+	match  string
+
+	recordRegex *regexp.Regexp
+	valueRegex  *regexp.Regexp
+}
+
+// expandDNSRecordFilters compiles the filter or exclude blocks of the configuration
+func expandDNSRecordFilters(v interface{}, attribute string) ([]dnsRecordFilter, error) {
+	blocks, _ := v.([]interface{})
+	filters := make([]dnsRecordFilter, 0, len(blocks))
+
+	for i, block := range blocks {
+		filterMap, ok := block.(map[string]interface{})
+		if !ok {
+			// an empty block matches every record
+			filters = append(filters, dnsRecordFilter{match: matchSubstring})
+			continue
+		}
+
+		filter := dnsRecordFilter{}
+		filter.record, _ = filterMap["record"].(string)
+		filter.typ, _ = filterMap["type"].(string)
+		filter.value, _ = filterMap["value"].(string)
+		filter.zone, _ = filterMap["zone"].(string)
+		filter.match, _ = filterMap["match"].(string)
+
+		var err error
+		switch filter.match {
+		case matchRegex:
+			if filter.recordRegex, err = compileFilterRegex(filter.record); err != nil {
+				return nil, errors.Wrapf(err, "invalid regex in %s.%d.record", attribute, i)
+			}
+			if filter.valueRegex, err = compileFilterRegex(filter.value); err != nil {
+				return nil, errors.Wrapf(err, "invalid regex in %s.%d.value", attribute, i)
+			}
+		case matchGlob:
+			if _, err := path.Match(filter.record, ""); err != nil {
+				return nil, errors.Wrapf(err, "invalid glob in %s.%d.record", attribute, i)
+			}
+			if _, err := path.Match(filter.value, ""); err != nil {
+				return nil, errors.Wrapf(err, "invalid glob in %s.%d.value", attribute, i)
+			}
+		}
+
+		filters = append(filters, filter)
+	}
+
+	return filters, nil
+}
+
+func compileFilterRegex(pattern string) (*regexp.Regexp, error) {
+	if pattern == "" {
+		return nil, nil
+	}
+	return regexp.Compile(pattern)
+}
+
+// filterDNSRecords returns the records of the given types that match any of the
+// filters (all records without filters) and none of the excludes
+func filterDNSRecords(
+	records []dreamhostapi.DNSRecord, filters, excludes []dnsRecordFilter, types []string,
+) []dreamhostapi.DNSRecord {
 	var filtered []dreamhostapi.DNSRecord
 
 	for _, record := range records {
-		if matchesFilter(record, filters) {
-			filtered = append(filtered, record)
+		if len(types) > 0 && !containsString(types, string(record.Type)) {
+			continue
+		}
+		if len(filters) > 0 && !matchesAnyFilter(record, filters) {
+			continue
+		}
+		if matchesAnyFilter(record, excludes) {
+			continue
 		}
+		filtered = append(filtered, record)
 	}
 
 	return filtered
 }
 
-func matchesFilter(record dreamhostapi.DNSRecord, filters map[string]interface{}) bool {
-	if recordFilter, ok := filters["record"].(string); ok && recordFilter != "" {
-		if !contains(record.Record, recordFilter) {
-			return false
+func matchesAnyFilter(record dreamhostapi.DNSRecord, filters []dnsRecordFilter) bool {
+	for i := range filters {
+		if matchesFilter(record, &filters[i]) {
+			return true
 		}
 	}
+	return false
+}
+
+func matchesFilter(record dreamhostapi.DNSRecord, filter *dnsRecordFilter) bool {
+	if filter.record != "" && !matchesPattern(record.Record, filter.record, filter.match, filter.recordRegex) {
+		return false
+	}
 
-	if typeFilter, ok := filters["type"].(string); ok && typeFilter != "" {
-		if string(record.Type) != typeFilter {
-			return false
-		}
+	if filter.typ != "" && string(record.Type) != filter.typ {
+		return false
 	}
 
-	if valueFilter, ok := filters["value"].(string); ok && valueFilter != "" {
-		if !contains(record.Value, valueFilter) {
-			return false
-		}
+	if filter.value != "" && !matchesPattern(record.Value, filter.value, filter.match, filter.valueRegex) {
+		return false
 	}
 
-	if zoneFilter, ok := filters["zone"].(string); ok && zoneFilter != "" {
-		if record.Zone != zoneFilter {
-			return false
-		}
+	if filter.zone != "" && record.Zone != filter.zone {
+		return false
 	}
 
 	return true
 }
 
+// matchesPattern matches s against a substring, an unanchored regex or a glob
+func matchesPattern(s, pattern, match string, compiled *regexp.Regexp) bool {
+	switch match {
+	case matchRegex:
+		return compiled.MatchString(s)
+	case matchGlob:
+		matched, _ := path.Match(pattern, s)
+		return matched
+	default:
+		return contains(s, pattern)
+	}
+}
+
 func contains(s, substr string) bool {
 	return len(substr) > 0 && len(s) >= len(substr) && (s == substr || containsSubstring(s, substr))
 }
@@ -198,3 +414,101 @@ func containsSubstring(s, substr string) bool {
 	return false
 }
 
+// sortDNSRecords sorts the records by the given key, breaking ties by record,
+// type and value so the order never depends on the API response
+func sortDNSRecords(records []dreamhostapi.DNSRecord, sortBy string) {
+	key := func(record dreamhostapi.DNSRecord) string {
+		switch sortBy {
+		case sortByType:
+			return string(record.Type)
+		case sortByValue:
+			return record.Value
+		case sortByZone:
+			return record.Zone
+		default:
+			return dnsRecordFQDN(record.Record)
+		}
+	}
+
+	sort.SliceStable(records, func(i, j int) bool {
+		a, b := records[i], records[j]
+		if ka, kb := key(a), key(b); ka != kb {
+			return ka < kb
+		}
+		if na, nb := dnsRecordFQDN(a.Record), dnsRecordFQDN(b.Record); na != nb {
+			return na < nb
+		}
+		if a.Type != b.Type {
+			return a.Type < b.Type
+		}
+		return a.Value < b.Value
+	})
+}
+
+// groupDNSRecordValues groups the sorted record values by name and type
+func groupDNSRecordValues(records []dreamhostapi.DNSRecord) []map[string]interface{} {
+	type groupKey struct {
+		name       string
+		recordType string
+	}
+	groups := make(map[groupKey][]string)
+	for _, record := range records {
+		key := groupKey{dnsRecordFQDN(record.Record), string(record.Type)}
+		groups[key] = append(groups[key], record.Value)
+	}
+
+	keys := make([]groupKey, 0, len(groups))
+	for key := range groups {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if keys[i].name != keys[j].name {
+			return keys[i].name < keys[j].name
+		}
+		return keys[i].recordType < keys[j].recordType
+	})
+
+	byName := make([]map[string]interface{}, 0, len(keys))
+	for _, key := range keys {
+		values := groups[key]
+		sort.Strings(values)
+		byName = append(byName, map[string]interface{}{
+			"name":   key.name,
+			"type":   key.recordType,
+			"values": values,
+		})
+	}
+	return byName
+}
+
+// dnsRecordValuesByName maps "<fqdn>/<type>" to the values of each group as a JSON
+// list; map values in the provider SDK must be strings, and unlike a separator JSON
+// keeps values containing commas or newlines intact
+func dnsRecordValuesByName(groups []map[string]interface{}) (map[string]interface{}, error) {
+	byName := make(map[string]interface{}, len(groups))
+	for _, group := range groups {
+		encoded, err := json.Marshal(group["values"])
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to encode record values")
+		}
+		byName[fmt.Sprintf("%s/%s", group["name"], group["type"])] = string(encoded)
+	}
+	return byName, nil
+}
+
+// dnsRecordZones returns the sorted, distinct zones of the records
+func dnsRecordZones(records []dreamhostapi.DNSRecord) []string {
+	zones := []string{}
+	for _, record := range records {
+		if record.Zone != "" && !containsString(zones, record.Zone) {
+			zones = append(zones, record.Zone)
+		}
+	}
+	sort.Strings(zones)
+	return zones
+}
+
+// dnsRecordFQDN normalizes a record name for grouping and sorting
+func dnsRecordFQDN(name string) string {
+	return strings.ToLower(strings.TrimSuffix(name, "."))
+}
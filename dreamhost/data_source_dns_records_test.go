@@ -0,0 +1,208 @@
+package dreamhost
+
+import (
+	"context"
+	"testing"
+
+	dreamhostapi "github.com/adamantal/go-dreamhost/api"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testFilterRecords() []dreamhostapi.DNSRecord {
+	return []dreamhostapi.DNSRecord{
+		{Record: "example.com", Type: dreamhostapi.ARecordType, Value: "192.0.2.1", Zone: "example.com"},
+		{Record: "www.example.com", Type: dreamhostapi.ARecordType, Value: "192.0.2.2", Zone: "example.com"},
+		{Record: "staging.example.com", Type: dreamhostapi.AAAARecordType, Value: "2001:db8::1", Zone: "example.com"},
+		{Record: "mail.example.com", Type: dreamhostapi.RecordType("MX"), Value: "10 mx.example.com.", Zone: "example.com"},
+		{Record: "example.org", Type: dreamhostapi.TXTRecordType, Value: "v=spf1 -all", Zone: "example.org"},
+	}
+}
+
+func TestFilterDNSRecords(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		filters  []interface{}
+		excludes []interface{}
+		types    []string
+		expected []string
+	}{
+		{
+			name:     "no filters",
+			expected: []string{"example.com", "www.example.com", "staging.example.com", "mail.example.com", "example.org"},
+		},
+		{
+			name:     "substring",
+			filters:  []interface{}{map[string]interface{}{"record": "example.c", "match": matchSubstring}},
+			expected: []string{"example.com", "www.example.com", "staging.example.com", "mail.example.com"},
+		},
+		{
+			name:     "regex",
+			filters:  []interface{}{map[string]interface{}{"record": `^(www|mail)\.`, "match": matchRegex}},
+			expected: []string{"www.example.com", "mail.example.com"},
+		},
+		{
+			name:     "glob",
+			filters:  []interface{}{map[string]interface{}{"record": "*.example.com", "match": matchGlob}},
+			expected: []string{"www.example.com", "staging.example.com", "mail.example.com"},
+		},
+		{
+			name: "filters are combined with OR",
+			filters: []interface{}{
+				map[string]interface{}{"type": "MX", "match": matchSubstring},
+				map[string]interface{}{"zone": "example.org", "match": matchSubstring},
+			},
+			expected: []string{"mail.example.com", "example.org"},
+		},
+		{
+			name:     "exclude",
+			filters:  []interface{}{map[string]interface{}{"zone": "example.com", "match": matchSubstring}},
+			excludes: []interface{}{map[string]interface{}{"record": "staging.*", "match": matchGlob}},
+			expected: []string{"example.com", "www.example.com", "mail.example.com"},
+		},
+		{
+			name:     "types",
+			types:    []string{"A", "AAAA"},
+			excludes: []interface{}{map[string]interface{}{"value": `1$`, "match": matchRegex}},
+			expected: []string{"www.example.com"},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			filters, err := expandDNSRecordFilters(tt.filters, "filter")
+			require.NoError(t, err)
+			excludes, err := expandDNSRecordFilters(tt.excludes, "exclude")
+			require.NoError(t, err)
+
+			var names []string
+			for _, record := range filterDNSRecords(testFilterRecords(), filters, excludes, tt.types) {
+				names = append(names, record.Record)
+			}
+			assert.Equal(t, tt.expected, names)
+		})
+	}
+}
+
+func TestExpandDNSRecordFiltersInvalidPattern(t *testing.T) {
+	t.Parallel()
+
+	_, err := expandDNSRecordFilters([]interface{}{
+		map[string]interface{}{"record": "(unclosed", "match": matchRegex},
+	}, "filter")
+	assert.ErrorContains(t, err, "invalid regex in filter.0.record")
+
+	_, err = expandDNSRecordFilters([]interface{}{
+		map[string]interface{}{"value": "[a-", "match": matchGlob},
+	}, "exclude")
+	assert.ErrorContains(t, err, "invalid glob in exclude.0.value")
+}
+
+func TestSortDNSRecords(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		sortBy   string
+		expected []string
+	}{
+		{sortByRecord, []string{"example.com", "example.org", "mail.example.com", "staging.example.com", "www.example.com"}},
+		{sortByType, []string{"example.com", "www.example.com", "staging.example.com", "mail.example.com", "example.org"}},
+		{sortByValue, []string{"mail.example.com", "example.com", "www.example.com", "staging.example.com", "example.org"}},
+		{sortByZone, []string{"example.com", "mail.example.com", "staging.example.com", "www.example.com", "example.org"}},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.sortBy, func(t *testing.T) {
+			t.Parallel()
+
+			records := testFilterRecords()
+			// reverse the input to make sure the order does not depend on it
+			for i, j := 0, len(records)-1; i < j; i, j = i+1, j-1 {
+				records[i], records[j] = records[j], records[i]
+			}
+			sortDNSRecords(records, tt.sortBy)
+
+			var names []string
+			for _, record := range records {
+				names = append(names, record.Record)
+			}
+			assert.Equal(t, tt.expected, names)
+		})
+	}
+}
+
+func TestGroupDNSRecordValues(t *testing.T) {
+	t.Parallel()
+
+	records := append(testFilterRecords(),
+		dreamhostapi.DNSRecord{Record: "WWW.example.com.", Type: dreamhostapi.ARecordType, Value: "192.0.2.0", Zone: "example.com"},
+	)
+
+	byName := groupDNSRecordValues(records)
+	require.Len(t, byName, 5)
+	values := map[string]interface{}{}
+	for _, group := range byName {
+		values[group["name"].(string)+"/"+group["type"].(string)] = group["values"]
+	}
+	assert.Equal(t, []string{"192.0.2.0", "192.0.2.2"}, values["www.example.com/A"])
+	assert.Equal(t, []string{"v=spf1 -all"}, values["example.org/TXT"])
+
+	// groups are ordered by name, then type
+	assert.Equal(t, "example.com", byName[0]["name"])
+	assert.Equal(t, "www.example.com", byName[4]["name"])
+
+	// the map form holds each group as a JSON list under "<fqdn>/<type>"
+	encoded, err := dnsRecordValuesByName(byName)
+	require.NoError(t, err)
+	assert.Len(t, encoded, 5)
+	assert.Equal(t, `["192.0.2.0","192.0.2.2"]`, encoded["www.example.com/A"])
+	assert.Equal(t, `["v=spf1 -all"]`, encoded["example.org/TXT"])
+
+	assert.Equal(t, []string{"example.com", "example.org"}, dnsRecordZones(records))
+	assert.Equal(t, []string{}, dnsRecordZones(nil))
+}
+
+func TestDataSourceDNSRecordsReadByName(t *testing.T) {
+	t.Parallel()
+
+	mock := NewMockDreamhostClient()
+	mock.SetRecords(testFilterRecords())
+
+	d := schema.TestResourceDataRaw(t, dataSourceDNSRecords().Schema, map[string]interface{}{})
+	diags := dataSourceDNSRecordsRead(context.Background(), d, newDreamhostClient(mock))
+	require.False(t, diags.HasError(), "%v", diags)
+	byName, _ := d.Get("by_name").(map[string]interface{})
+	assert.Equal(t, `["192.0.2.2"]`, byName["www.example.com/A"])
+	assert.Equal(t, `["10 mx.example.com."]`, byName["mail.example.com/MX"])
+	assert.Equal(t, "example.com", d.Get("groups.0.name"))
+	assert.Equal(t, []interface{}{"192.0.2.1"}, d.Get("groups.0.values"))
+}
+
+func TestHashID(t *testing.T) {
+	t.Parallel()
+
+	query := func(ids ...string) map[string]interface{} {
+		return map[string]interface{}{
+			"filter":  []interface{}{map[string]interface{}{"type": "A", "match": matchSubstring}},
+			"sort_by": sortByRecord,
+			"ids":     ids,
+		}
+	}
+
+	first, err := hashID(query("A|example.com|192.0.2.1"))
+	require.NoError(t, err)
+	second, err := hashID(query("A|example.com|192.0.2.1"))
+	require.NoError(t, err)
+	other, err := hashID(query("A|example.com|192.0.2.2"))
+	require.NoError(t, err)
+
+	assert.Equal(t, first, second)
+	assert.NotEqual(t, first, other)
+}
@@ -0,0 +1,191 @@
+package dreamhost
+
+import (
+	"context"
+	"sort"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func dataSourceDNSResolution() *schema.Resource {
+	resolvable := make([]string, 0, len(resolvableTypes))
+	for recordType := range resolvableTypes {
+		resolvable = append(resolvable, recordType)
+	}
+	sort.Strings(resolvable)
+
+	return &schema.Resource{
+		ReadContext: dataSourceDNSResolutionRead,
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: ValidateDomainName(),
+				Description:  "The name to resolve",
+			},
+			"type": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validation.StringInSlice(resolvable, false),
+				Description:  "The record type to query (A, AAAA, CNAME, MX, NS, PTR, SRV, TXT)",
+			},
+			"nameservers": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Description: "Resolvers or authoritative nameservers to query, as host or host:port; defaults to the DreamHost nameservers",
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+			"protocol": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      dnsNetworkUDP,
+				ValidateFunc: validation.StringInSlice([]string{dnsNetworkUDP, dnsNetworkTCP}, false),
+				Description:  "Query over udp (default, retried over TCP when truncated) or tcp",
+			},
+			"recursion_desired": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     true,
+				Description: "Ask the nameservers to recurse, required when querying resolvers",
+			},
+			// Computed fields
+			"answers": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "The answer of each nameserver, in the order of `nameservers`",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"nameserver": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The queried nameserver",
+						},
+						"rcode": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The response code, e.g. NOERROR or NXDOMAIN",
+						},
+						"authoritative": {
+							Type:        schema.TypeBool,
+							Computed:    true,
+							Description: "Whether the answer is authoritative",
+						},
+						"values": {
+							Type:        schema.TypeList,
+							Computed:    true,
+							Description: "The sorted, normalized values of the answer",
+							Elem:        &schema.Schema{Type: schema.TypeString},
+						},
+						"error": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Why the nameserver could not be queried, empty when it answered",
+						},
+					},
+				},
+			},
+			"values": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "The sorted values served by all nameservers",
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+			"api_values": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "The sorted, normalized values in the cached DreamHost listing",
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+			"consistent": {
+				Type:        schema.TypeBool,
+				Computed:    true,
+				Description: "Whether all nameservers answered with the same values",
+			},
+			"consistent_with_api": {
+				Type:        schema.TypeBool,
+				Computed:    true,
+				Description: "Whether every nameserver answered with exactly the values of the DreamHost listing",
+			},
+		},
+	}
+}
+
+func dataSourceDNSResolutionRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	api, ok := meta.(*cachedDreamhostClient)
+	if !ok {
+		return diag.Errorf("internal error: failed to retrieve dreamhost API client")
+	}
+
+	name, _ := d.Get("name").(string)
+	recordType, _ := d.Get("type").(string)
+	network, _ := d.Get("protocol").(string)
+	recursionDesired, _ := d.Get("recursion_desired").(bool)
+	nameservers := expandStringList(d.Get("nameservers").([]interface{}))
+	if len(nameservers) == 0 {
+		nameservers = dreamhostNameservers
+	}
+
+	resolutions, err := resolveDNS(ctx, nameservers, network, name, recordType, recursionDesired)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	records, err := api.ListCachedDNSRecords(ctx)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	apiValues := apiRecordValues(records, name, recordType)
+
+	answers := make([]map[string]interface{}, 0, len(resolutions))
+	values := []string{}
+	consistent, consistentWithAPI := true, true
+	for _, resolution := range resolutions {
+		answers = append(answers, map[string]interface{}{
+			"nameserver":    resolution.Nameserver,
+			"rcode":         resolution.RCode,
+			"authoritative": resolution.Authoritative,
+			"values":        resolution.Values,
+			"error":         resolution.Error,
+		})
+		for _, value := range resolution.Values {
+			if !containsString(values, value) {
+				values = append(values, value)
+			}
+		}
+		// a nameserver that did not answer agrees with neither
+		answered := resolution.Error == ""
+		consistent = consistent && answered && sameValues(resolution.Values, resolutions[0].Values)
+		consistentWithAPI = consistentWithAPI && answered && sameValues(resolution.Values, apiValues)
+	}
+	sort.Strings(values)
+
+	fields := map[string]interface{}{
+		"answers":             answers,
+		"values":              values,
+		"api_values":          apiValues,
+		"consistent":          consistent,
+		"consistent_with_api": consistentWithAPI,
+	}
+	for key, value := range fields {
+		if err := d.Set(key, value); err != nil {
+			return diag.Errorf("failed to set %s: %v", key, err)
+		}
+	}
+
+	// the ID changes with the answers so a changed resolution shows up in plans
+	id, err := hashID(map[string]interface{}{
+		"name":    dnsRecordFQDN(name),
+		"type":    recordType,
+		"answers": answers,
+	})
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	d.SetId(id)
+
+	return diags
+}
@@ -0,0 +1,92 @@
+package dreamhost
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func dataSourceDNSZoneExport() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceDNSZoneExportRead,
+		Schema: map[string]*schema.Schema{
+			"zone": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: ValidateDomainName(),
+				Description:  "The zone to export",
+			},
+			"format": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      zoneExportBIND,
+				ValidateFunc: validation.StringInSlice([]string{zoneExportBIND, zoneExportJSON, zoneExportCSV}, false),
+				Description:  "The export format: bind (default), json or csv",
+			},
+			"ttl": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      defaultZoneTTL,
+				ValidateFunc: validation.IntAtLeast(0),
+				Description:  "The $TTL of the BIND zone file, the API does not report per-record TTLs",
+			},
+			// Computed fields
+			"content": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The rendered zone",
+			},
+			"record_count": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "Number of exported records",
+			},
+		},
+	}
+}
+
+func dataSourceDNSZoneExportRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	api, ok := meta.(*cachedDreamhostClient)
+	if !ok {
+		return diag.Errorf("internal error: failed to retrieve dreamhost API client")
+	}
+
+	zone, _ := d.Get("zone").(string)
+	format, _ := d.Get("format").(string)
+	ttl, _ := d.Get("ttl").(int)
+
+	records, err := api.ListCachedDNSRecords(ctx)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	exported := zoneExportRecords(records, zone)
+	if len(exported) == 0 {
+		return diag.Errorf("zone %s has no records in the account", zone)
+	}
+
+	content, err := renderZoneExport(zone, exported, format, ttl)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := d.Set("content", content); err != nil {
+		return diag.Errorf("failed to set content: %v", err)
+	}
+	if err := d.Set("record_count", len(exported)); err != nil {
+		return diag.Errorf("failed to set record_count: %v", err)
+	}
+
+	// the content is deterministic, so its hash only changes with the zone
+	id, err := hashID(map[string]interface{}{"content": content})
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	d.SetId(id)
+
+	return diags
+}
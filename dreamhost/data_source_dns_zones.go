@@ -0,0 +1,122 @@
+package dreamhost
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourceDNSZones() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceDNSZonesRead,
+		Schema: map[string]*schema.Schema{
+			"names": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "Sorted list of the zone names",
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+			"zones": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "List of DNS zones, sorted by name",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The zone name",
+						},
+						"record_count": {
+							Type:        schema.TypeInt,
+							Computed:    true,
+							Description: "Number of records in the zone",
+						},
+						"record_counts": {
+							Type:        schema.TypeMap,
+							Computed:    true,
+							Description: "Number of records in the zone by record type",
+							Elem:        &schema.Schema{Type: schema.TypeInt},
+						},
+						"editable_count": {
+							Type:        schema.TypeInt,
+							Computed:    true,
+							Description: "Number of records that can be managed through the API",
+						},
+						"non_editable_count": {
+							Type:        schema.TypeInt,
+							Computed:    true,
+							Description: "Number of records managed by DreamHost",
+						},
+						"nameservers": {
+							Type:        schema.TypeList,
+							Computed:    true,
+							Description: "Sorted values of the apex NS records",
+							Elem:        &schema.Schema{Type: schema.TypeString},
+						},
+						"dreamhost_nameservers": {
+							Type:        schema.TypeBool,
+							Computed:    true,
+							Description: "Whether the zone has apex NS records and all of them point at DreamHost",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceDNSZonesRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	api, ok := meta.(*cachedDreamhostClient)
+	if !ok {
+		return diag.Errorf("internal error: failed to retrieve dreamhost API client")
+	}
+
+	records, err := api.ListCachedDNSRecords(ctx)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	zones := summarizeDNSZones(records)
+
+	names := make([]string, 0, len(zones))
+	zoneList := make([]map[string]interface{}, 0, len(zones))
+	for _, zone := range zones {
+		names = append(names, zone.Name)
+
+		counts := make(map[string]interface{}, len(zone.RecordCounts))
+		for recordType, count := range zone.RecordCounts {
+			counts[recordType] = count
+		}
+		zoneList = append(zoneList, map[string]interface{}{
+			"name":                  zone.Name,
+			"record_count":          zone.RecordCount,
+			"record_counts":         counts,
+			"editable_count":        zone.EditableCount,
+			"non_editable_count":    zone.NonEditableCount,
+			"nameservers":           zone.Nameservers,
+			"dreamhost_nameservers": zone.DreamHostNameservers(),
+		})
+	}
+
+	fields := map[string]interface{}{
+		"names": names,
+		"zones": zoneList,
+	}
+	for key, value := range fields {
+		if err := d.Set(key, value); err != nil {
+			return diag.Errorf("failed to set %s: %v", key, err)
+		}
+	}
+
+	id, err := hashID(map[string]interface{}{"zones": zoneList})
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	d.SetId(id)
+
+	return diags
+}
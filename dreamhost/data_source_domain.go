@@ -0,0 +1,55 @@
+package dreamhost
+
+import (
+	"context"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourceDomain() *schema.Resource {
+	attributes := domainSchema()
+	attributes["domain"] = &schema.Schema{
+		Type:         schema.TypeString,
+		Required:     true,
+		ValidateFunc: ValidateDomainName(),
+		Description:  "The domain name to look up",
+	}
+
+	return &schema.Resource{
+		ReadContext: dataSourceDomainRead,
+		Schema:      attributes,
+	}
+}
+
+func dataSourceDomainRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	api, ok := meta.(*cachedDreamhostClient)
+	if !ok {
+		return diag.Errorf("internal error: failed to retrieve dreamhost API client")
+	}
+
+	name, _ := d.Get("domain").(string)
+
+	domains, err := listDomains(ctx, api)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	for _, domain := range domains {
+		if !strings.EqualFold(domain.Domain, strings.TrimSuffix(name, ".")) {
+			continue
+		}
+		for key, value := range flattenDomain(domain) {
+			if err := d.Set(key, value); err != nil {
+				return diag.Errorf("failed to set %s: %v", key, err)
+			}
+		}
+		d.SetId(domain.Domain)
+		return diags
+	}
+
+	return diag.Errorf("domain not found: %s", name)
+}
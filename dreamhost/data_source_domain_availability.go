@@ -0,0 +1,118 @@
+package dreamhost
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourceDomainAvailability() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceDomainAvailabilityRead,
+		Schema: map[string]*schema.Schema{
+			"domains": {
+				Type:        schema.TypeList,
+				Required:    true,
+				MinItems:    1,
+				Description: "The candidate domains to check",
+				Elem: &schema.Schema{
+					Type:         schema.TypeString,
+					ValidateFunc: ValidateDomainName(),
+				},
+			},
+			// Computed fields
+			"results": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "The availability of each distinct candidate, in the order of `domains`",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"domain": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The candidate domain",
+						},
+						"available": {
+							Type:        schema.TypeBool,
+							Computed:    true,
+							Description: "Whether the domain can be registered",
+						},
+						"price": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The registration price, if reported by the API",
+						},
+						"details": {
+							Type:        schema.TypeMap,
+							Computed:    true,
+							Description: "All fields of the API response, e.g. pricing or eligibility data",
+							Elem:        &schema.Schema{Type: schema.TypeString},
+						},
+						"error": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The error the API rejected this candidate with, such as an invalid TLD",
+						},
+					},
+				},
+			},
+			"available": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "The candidates that can be registered, in the order of `domains`",
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+		},
+	}
+}
+
+func dataSourceDomainAvailabilityRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	api, ok := meta.(*cachedDreamhostClient)
+	if !ok {
+		return diag.Errorf("internal error: failed to retrieve dreamhost API client")
+	}
+
+	candidates := expandStringList(d.Get("domains").([]interface{}))
+
+	results, err := checkDomainAvailability(ctx, api, candidates)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	resultList := make([]map[string]interface{}, 0, len(results))
+	available := []string{}
+	for _, result := range results {
+		details := make(map[string]interface{}, len(result.Details))
+		for key, value := range result.Details {
+			details[key] = value
+		}
+		resultList = append(resultList, map[string]interface{}{
+			"domain":    result.Domain,
+			"available": result.Available,
+			"price":     result.Price,
+			"details":   details,
+			"error":     result.Error,
+		})
+		if result.Available {
+			available = append(available, result.Domain)
+		}
+	}
+
+	if err := d.Set("results", resultList); err != nil {
+		return diag.Errorf("failed to set results: %v", err)
+	}
+	if err := d.Set("available", available); err != nil {
+		return diag.Errorf("failed to set available: %v", err)
+	}
+
+	id, err := hashID(map[string]interface{}{"results": resultList})
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	d.SetId(id)
+
+	return diags
+}
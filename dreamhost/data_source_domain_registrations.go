@@ -0,0 +1,173 @@
+package dreamhost
+
+import (
+	"context"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// noExpiryCheck is the value of expiring_within_days when it is not set
+const noExpiryCheck = -1
+
+func dataSourceDomainRegistrations() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceDomainRegistrationsRead,
+		Schema: map[string]*schema.Schema{
+			// 0 selects registrations expiring today or already expired, so unset needs its own value
+			"expiring_within_days": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      noExpiryCheck,
+				ValidateFunc: validation.IntAtLeast(0),
+				Description:  "Emit a warning for every registration expiring within this many days, 0 for today",
+			},
+			// Computed fields
+			"registrations": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "List of domain registrations, sorted by domain",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"domain": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The registered domain",
+						},
+						"status": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The registrar status",
+						},
+						"created": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "When the domain was registered, as reported by the API",
+						},
+						"expires": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The expiry date (YYYY-MM-DD)",
+						},
+						"days_until_expiry": {
+							Type:        schema.TypeInt,
+							Computed:    true,
+							Description: "Days until the registration expires, negative once expired",
+						},
+						"autorenew": {
+							Type:        schema.TypeBool,
+							Computed:    true,
+							Description: "Whether the registration renews automatically",
+						},
+						"locked": {
+							Type:        schema.TypeBool,
+							Computed:    true,
+							Description: "Whether the domain is locked against transfers",
+						},
+						"nameservers": {
+							Type:        schema.TypeList,
+							Computed:    true,
+							Description: "The delegated nameservers",
+							Elem:        &schema.Schema{Type: schema.TypeString},
+						},
+						"dreamhost_nameservers": {
+							Type:        schema.TypeBool,
+							Computed:    true,
+							Description: "Whether the domain is delegated to DreamHost nameservers only",
+						},
+					},
+				},
+			},
+			"expiring": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "Domains expiring within `expiring_within_days`, or already expired",
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+			"nameservers_not_dreamhost": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "Domains not delegated to DreamHost nameservers only",
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+		},
+	}
+}
+
+func dataSourceDomainRegistrationsRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	api, ok := meta.(*cachedDreamhostClient)
+	if !ok {
+		return diag.Errorf("internal error: failed to retrieve dreamhost API client")
+	}
+
+	registrations, err := listRegistrations(ctx, api)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	now := time.Now().UTC()
+	withinDays, _ := d.Get("expiring_within_days").(int)
+	checkExpiry := withinDays != noExpiryCheck
+
+	registrationList := make([]map[string]interface{}, 0, len(registrations))
+	expiring := []string{}
+	notDreamHost := []string{}
+	for _, registration := range registrations {
+		item := map[string]interface{}{
+			"domain":                registration.Domain,
+			"status":                registration.RegistrarStatus(),
+			"created":               registration.Created,
+			"expires":               registration.Expires,
+			"days_until_expiry":     0,
+			"autorenew":             isAPIFlagSet(registration.Autorenew),
+			"locked":                isAPIFlagSet(registration.Locked),
+			"nameservers":           registration.Nameservers(),
+			"dreamhost_nameservers": registration.DreamHostNameservers(),
+		}
+		if expires, err := registration.ExpiresAt(); err == nil {
+			days := daysUntil(expires, now)
+			item["expires"] = expires.Format("2006-01-02")
+			item["days_until_expiry"] = days
+			if checkExpiry && days <= withinDays {
+				expiring = append(expiring, registration.Domain)
+			}
+		}
+		if !registration.DreamHostNameservers() {
+			notDreamHost = append(notDreamHost, registration.Domain)
+		}
+		registrationList = append(registrationList, item)
+	}
+
+	fields := map[string]interface{}{
+		"registrations":             registrationList,
+		"expiring":                  expiring,
+		"nameservers_not_dreamhost": notDreamHost,
+	}
+	for key, value := range fields {
+		if err := d.Set(key, value); err != nil {
+			return diag.Errorf("failed to set %s: %v", key, err)
+		}
+	}
+
+	// days_until_expiry changes daily, keep it out of the ID
+	ids := make([]string, 0, len(registrations))
+	for _, registration := range registrations {
+		ids = append(ids, registration.Domain+"|"+registration.Expires)
+	}
+	id, err := hashID(map[string]interface{}{"registrations": ids})
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	d.SetId(id)
+
+	// data sources are read during plan, so the warnings show up there
+	if checkExpiry {
+		diags = append(diags, registrationWarnings(registrations, withinDays, now)...)
+	}
+
+	return diags
+}
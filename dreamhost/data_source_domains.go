@@ -0,0 +1,127 @@
+package dreamhost
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourceDomains() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceDomainsRead,
+		Schema: map[string]*schema.Schema{
+			"type": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Only return domains of this type (e.g. http, mirror, redirect, parked)",
+			},
+			"hosting_type": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Only return domains with this hosting type",
+			},
+			"server": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Only return domains hosted on this server, as hostname or short name",
+			},
+			// Computed fields
+			"names": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "Sorted list of the returned domain names",
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+			"domains": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "List of hosted domains, sorted by name",
+				Elem: &schema.Resource{
+					Schema: domainSchema(),
+				},
+			},
+		},
+	}
+}
+
+// domainSchema returns the computed attributes of a hosted domain
+func domainSchema() map[string]*schema.Schema {
+	attributes := map[string]struct {
+		valueType   schema.ValueType
+		description string
+	}{
+		"domain":        {schema.TypeString, "The domain name"},
+		"account_id":    {schema.TypeString, "Account ID of the domain"},
+		"type":          {schema.TypeString, "The domain type (e.g. http, mirror, redirect, parked)"},
+		"hosting_type":  {schema.TypeString, "The hosting type"},
+		"home":          {schema.TypeString, "The server hosting the domain"},
+		"unique_ip":     {schema.TypeString, "The unique IP address of the domain, if any"},
+		"user":          {schema.TypeString, "The user the web files belong to"},
+		"path":          {schema.TypeString, "The web directory relative to the user's home"},
+		"outside_url":   {schema.TypeString, "The target of a redirect or mirror"},
+		"www_mode":      {schema.TypeString, "How www is handled: add_www, remove_www or both_work"},
+		"www_prefixed":  {schema.TypeBool, "Whether requests are redirected to the www-prefixed name"},
+		"php_version":   {schema.TypeString, "The PHP version"},
+		"php_fcgid":     {schema.TypeBool, "Whether PHP runs as FastCGI"},
+		"fastcgi":       {schema.TypeBool, "Whether FastCGI is enabled"},
+		"passenger":     {schema.TypeBool, "Whether Passenger is enabled"},
+		"https_enabled": {schema.TypeBool, "Whether the API reports HTTPS as enabled"},
+	}
+
+	result := make(map[string]*schema.Schema, len(attributes))
+	for name, attribute := range attributes {
+		result[name] = &schema.Schema{
+			Type:        attribute.valueType,
+			Computed:    true,
+			Description: attribute.description,
+		}
+	}
+	return result
+}
+
+func dataSourceDomainsRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	api, ok := meta.(*cachedDreamhostClient)
+	if !ok {
+		return diag.Errorf("internal error: failed to retrieve dreamhost API client")
+	}
+
+	domainType, _ := d.Get("type").(string)
+	hostingType, _ := d.Get("hosting_type").(string)
+	server, _ := d.Get("server").(string)
+
+	domains, err := listDomains(ctx, api)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	domains = filterDomains(domains, domainType, hostingType, server)
+
+	names := make([]string, 0, len(domains))
+	domainList := make([]map[string]interface{}, 0, len(domains))
+	for _, domain := range domains {
+		names = append(names, domain.Domain)
+		domainList = append(domainList, flattenDomain(domain))
+	}
+
+	if err := d.Set("names", names); err != nil {
+		return diag.Errorf("failed to set names: %v", err)
+	}
+	if err := d.Set("domains", domainList); err != nil {
+		return diag.Errorf("failed to set domains: %v", err)
+	}
+
+	id, err := hashID(map[string]interface{}{
+		"type":         domainType,
+		"hosting_type": hostingType,
+		"server":       server,
+		"domains":      domainList,
+	})
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	d.SetId(id)
+
+	return diags
+}
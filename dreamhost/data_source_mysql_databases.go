@@ -0,0 +1,110 @@
+package dreamhost
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourceMySQLDatabases() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceMySQLDatabasesRead,
+		Schema: map[string]*schema.Schema{
+			"home": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Only return databases on this database server, as hostname or short name",
+			},
+			"database": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Only return the database with this name",
+			},
+			// Computed fields
+			"names": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "Sorted list of the returned database names",
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+			"databases": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "List of databases, sorted by name",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The database name",
+						},
+						"description": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The description of the database",
+						},
+						"home": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The database server hosting the database",
+						},
+						"disk_usage_mb": {
+							Type:        schema.TypeFloat,
+							Computed:    true,
+							Description: "The disk usage of the database in megabytes",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceMySQLDatabasesRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	api, ok := meta.(*cachedDreamhostClient)
+	if !ok {
+		return diag.Errorf("internal error: failed to retrieve dreamhost API client")
+	}
+
+	home, _ := d.Get("home").(string)
+	database, _ := d.Get("database").(string)
+
+	databases, err := listMySQLDatabases(ctx, api, home, database)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	names := make([]string, 0, len(databases))
+	databaseList := make([]map[string]interface{}, 0, len(databases))
+	for _, db := range databases {
+		names = append(names, db.Database)
+		databaseList = append(databaseList, map[string]interface{}{
+			"name":          db.Database,
+			"description":   db.Description,
+			"home":          db.Home,
+			"disk_usage_mb": parseDiskUsage(db.DiskUsageMB),
+		})
+	}
+
+	if err := d.Set("names", names); err != nil {
+		return diag.Errorf("failed to set names: %v", err)
+	}
+	if err := d.Set("databases", databaseList); err != nil {
+		return diag.Errorf("failed to set databases: %v", err)
+	}
+
+	id, err := hashID(map[string]interface{}{
+		"home":      home,
+		"database":  database,
+		"databases": databaseList,
+	})
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	d.SetId(id)
+
+	return diags
+}
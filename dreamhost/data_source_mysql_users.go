@@ -0,0 +1,127 @@
+package dreamhost
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourceMySQLUsers() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceMySQLUsersRead,
+		Schema: map[string]*schema.Schema{
+			"home": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Only return users on this database server, as hostname or short name",
+			},
+			"database": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Only return users of this database",
+			},
+			"username": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Only return grants of this user",
+			},
+			// Computed fields
+			"usernames": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "Sorted list of the distinct returned usernames",
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+			"users": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "List of user grants, one per user, database and host, sorted by username, database and host",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"username": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The username",
+						},
+						"database": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The database the grant applies to",
+						},
+						"home": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The database server hosting the database",
+						},
+						"host": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The host pattern the user may connect from",
+						},
+						"privileges": {
+							Type:        schema.TypeList,
+							Computed:    true,
+							Description: "The granted privileges (select, insert, update, delete, create, drop, index, alter)",
+							Elem:        &schema.Schema{Type: schema.TypeString},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceMySQLUsersRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	api, ok := meta.(*cachedDreamhostClient)
+	if !ok {
+		return diag.Errorf("internal error: failed to retrieve dreamhost API client")
+	}
+
+	home, _ := d.Get("home").(string)
+	database, _ := d.Get("database").(string)
+	username, _ := d.Get("username").(string)
+
+	users, err := listMySQLUsers(ctx, api, home, database, username)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	usernames := []string{}
+	userList := make([]map[string]interface{}, 0, len(users))
+	for _, user := range users {
+		// users are sorted by username, so duplicates are adjacent
+		if len(usernames) == 0 || usernames[len(usernames)-1] != user.Username {
+			usernames = append(usernames, user.Username)
+		}
+		userList = append(userList, map[string]interface{}{
+			"username":   user.Username,
+			"database":   user.Database,
+			"home":       user.Home,
+			"host":       user.Host,
+			"privileges": user.privileges(),
+		})
+	}
+
+	if err := d.Set("usernames", usernames); err != nil {
+		return diag.Errorf("failed to set usernames: %v", err)
+	}
+	if err := d.Set("users", userList); err != nil {
+		return diag.Errorf("failed to set users: %v", err)
+	}
+
+	id, err := hashID(map[string]interface{}{
+		"home":     home,
+		"database": database,
+		"username": username,
+		"users":    userList,
+	})
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	d.SetId(id)
+
+	return diags
+}
@@ -0,0 +1,117 @@
+package dreamhost
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourcePrivateServerHistory() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourcePrivateServerHistoryRead,
+		Schema: map[string]*schema.Schema{
+			"ps": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "The name of the private server, e.g. ps12345",
+			},
+			"timezone": privateServerTimezoneSchema(),
+			// Computed fields
+			"resizes": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "Memory size changes, oldest first",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"timestamp": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The time of the change in RFC 3339",
+						},
+						"memory_mb": {
+							Type:        schema.TypeInt,
+							Computed:    true,
+							Description: "The memory size in megabytes from this time on",
+						},
+						"period_cost": {
+							Type:        schema.TypeFloat,
+							Computed:    true,
+							Description: "The cost of the period at this size",
+						},
+						"monthly_cost": {
+							Type:        schema.TypeFloat,
+							Computed:    true,
+							Description: "The monthly cost at this size",
+						},
+					},
+				},
+			},
+			"reboots": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "Reboot times in RFC 3339, oldest first",
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+			"last_reboot": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The time of the last reboot in RFC 3339, empty when there was none",
+			},
+		},
+	}
+}
+
+func dataSourcePrivateServerHistoryRead(
+	ctx context.Context, d *schema.ResourceData, meta interface{},
+) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	api, ok := meta.(*cachedDreamhostClient)
+	if !ok {
+		return diag.Errorf("internal error: failed to retrieve dreamhost API client")
+	}
+
+	name, _ := d.Get("ps").(string)
+	timezone, _ := d.Get("timezone").(string)
+	location, err := loadAPITimezone(timezone)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	resizes, err := privateServerResizes(ctx, api, name, location)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	reboots, err := privateServerReboots(ctx, api, name, location)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	lastReboot := ""
+	if len(reboots) > 0 {
+		lastReboot = reboots[len(reboots)-1]
+	}
+
+	fields := map[string]interface{}{
+		"resizes":     resizes,
+		"reboots":     reboots,
+		"last_reboot": lastReboot,
+	}
+	for key, value := range fields {
+		if err := d.Set(key, value); err != nil {
+			return diag.Errorf("failed to set %s: %v", key, err)
+		}
+	}
+
+	id, err := hashID(map[string]interface{}{
+		"ps":      name,
+		"resizes": resizes,
+		"reboots": reboots,
+	})
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	d.SetId(id)
+
+	return diags
+}
@@ -0,0 +1,111 @@
+package dreamhost
+
+import (
+	"context"
+	"net/url"
+	"sort"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// imageNameKey is the column dreamhost_ps-list_images reports the image name in
+const imageNameKey = "image"
+
+func dataSourcePrivateServerImages() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourcePrivateServerImagesRead,
+		Schema: map[string]*schema.Schema{
+			"ps": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Only return images available to this private server",
+			},
+			// Computed fields
+			"names": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "Sorted list of the image names",
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+			"images": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "List of images, sorted by name",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The image name",
+						},
+						"details": {
+							Type:        schema.TypeMap,
+							Computed:    true,
+							Description: "All fields the API returned for the image",
+							Elem:        &schema.Schema{Type: schema.TypeString},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourcePrivateServerImagesRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	api, ok := meta.(*cachedDreamhostClient)
+	if !ok {
+		return diag.Errorf("internal error: failed to retrieve dreamhost API client")
+	}
+
+	name, _ := d.Get("ps").(string)
+	var params url.Values
+	if name != "" {
+		params = privateServerParams(name)
+	}
+
+	records, err := listPrivateServerRecords(ctx, api, listPrivateServerImagesCmd, params, imageNameKey)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	images := make([]map[string]interface{}, 0, len(records))
+	for _, record := range records {
+		image := record[imageNameKey]
+		if image == "" {
+			return diag.Errorf("unexpected %s response: entry has no %s: %v", listPrivateServerImagesCmd, imageNameKey, record)
+		}
+		images = append(images, map[string]interface{}{"name": image, "details": record})
+	}
+	sort.SliceStable(images, func(i, j int) bool {
+		left, _ := images[i]["name"].(string)
+		right, _ := images[j]["name"].(string)
+		return left < right
+	})
+
+	names := make([]string, 0, len(images))
+	for _, image := range images {
+		imageName, _ := image["name"].(string)
+		names = append(names, imageName)
+	}
+
+	if err := d.Set("names", names); err != nil {
+		return diag.Errorf("failed to set names: %v", err)
+	}
+	if err := d.Set("images", images); err != nil {
+		return diag.Errorf("failed to set images: %v", err)
+	}
+
+	id, err := hashID(map[string]interface{}{
+		"ps":     name,
+		"images": images,
+	})
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	d.SetId(id)
+
+	return diags
+}
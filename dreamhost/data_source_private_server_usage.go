@@ -0,0 +1,120 @@
+package dreamhost
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// privateServerTimezoneSchema is the timezone argument of the private server history data sources
+func privateServerTimezoneSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:        schema.TypeString,
+		Optional:    true,
+		Default:     defaultAPITimezone,
+		Description: "IANA timezone the API times without an offset are in; results are always in UTC",
+	}
+}
+
+func dataSourcePrivateServerUsage() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourcePrivateServerUsageRead,
+		Schema: map[string]*schema.Schema{
+			"ps": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "The name of the private server, e.g. ps12345",
+			},
+			"timezone": privateServerTimezoneSchema(),
+			// Computed fields
+			"usage": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "Usage samples, oldest first",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"timestamp": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The time of the sample in RFC 3339",
+						},
+						"memory_mb": {
+							Type:        schema.TypeFloat,
+							Computed:    true,
+							Description: "The memory in use in megabytes",
+						},
+						"load": {
+							Type:        schema.TypeFloat,
+							Computed:    true,
+							Description: "The CPU load",
+						},
+					},
+				},
+			},
+			"peak_memory_mb": {
+				Type:        schema.TypeFloat,
+				Computed:    true,
+				Description: "The highest memory use of the samples in megabytes",
+			},
+			"peak_load": {
+				Type:        schema.TypeFloat,
+				Computed:    true,
+				Description: "The highest CPU load of the samples",
+			},
+		},
+	}
+}
+
+func dataSourcePrivateServerUsageRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	api, ok := meta.(*cachedDreamhostClient)
+	if !ok {
+		return diag.Errorf("internal error: failed to retrieve dreamhost API client")
+	}
+
+	name, _ := d.Get("ps").(string)
+	timezone, _ := d.Get("timezone").(string)
+	location, err := loadAPITimezone(timezone)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	usage, err := privateServerUsage(ctx, api, name, location)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	var peakMemory, peakLoad float64
+	for _, point := range usage {
+		if memory, _ := point["memory_mb"].(float64); memory > peakMemory {
+			peakMemory = memory
+		}
+		if load, _ := point["load"].(float64); load > peakLoad {
+			peakLoad = load
+		}
+	}
+
+	fields := map[string]interface{}{
+		"usage":          usage,
+		"peak_memory_mb": peakMemory,
+		"peak_load":      peakLoad,
+	}
+	for key, value := range fields {
+		if err := d.Set(key, value); err != nil {
+			return diag.Errorf("failed to set %s: %v", key, err)
+		}
+	}
+
+	id, err := hashID(map[string]interface{}{
+		"ps":    name,
+		"usage": usage,
+	})
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	d.SetId(id)
+
+	return diags
+}
@@ -0,0 +1,133 @@
+package dreamhost
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourceUsers() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceUsersRead,
+		Schema: map[string]*schema.Schema{
+			"type": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Only return users of this type (e.g. shell, sftp, ftp)",
+			},
+			"server": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Only return users on this server, as hostname or short name",
+			},
+			// Computed fields
+			"usernames": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "Sorted list of the returned usernames",
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+			"users": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "List of users, sorted by username",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"username": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The username",
+						},
+						"type": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The user type",
+						},
+						"server": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The server the user lives on",
+						},
+						"shell": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The login shell of the user",
+						},
+						"gecos": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The full name of the user",
+						},
+						"disk_used_mb": {
+							Type:        schema.TypeFloat,
+							Computed:    true,
+							Description: "The disk usage of the user in megabytes",
+						},
+						"quota_mb": {
+							Type:        schema.TypeFloat,
+							Computed:    true,
+							Description: "The disk quota of the user in megabytes, 0 when unlimited",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceUsersRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	api, ok := meta.(*cachedDreamhostClient)
+	if !ok {
+		return diag.Errorf("internal error: failed to retrieve dreamhost API client")
+	}
+
+	userType, _ := d.Get("type").(string)
+	server, _ := d.Get("server").(string)
+
+	users, err := listUsers(ctx, api)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	users = filterUsers(users, userType, server)
+
+	usernames := make([]string, 0, len(users))
+	userList := make([]map[string]interface{}, 0, len(users))
+	for _, user := range users {
+		diskUsed, _ := strconv.ParseFloat(user.DiskUsedMB, 64)
+		quota, _ := strconv.ParseFloat(user.QuotaMB, 64)
+		usernames = append(usernames, user.Username)
+		userList = append(userList, map[string]interface{}{
+			"username":     user.Username,
+			"type":         user.Type,
+			"server":       user.Home,
+			"shell":        user.Shell,
+			"gecos":        user.Gecos,
+			"disk_used_mb": diskUsed,
+			"quota_mb":     quota,
+		})
+	}
+
+	if err := d.Set("usernames", usernames); err != nil {
+		return diag.Errorf("failed to set usernames: %v", err)
+	}
+	if err := d.Set("users", userList); err != nil {
+		return diag.Errorf("failed to set users: %v", err)
+	}
+
+	// disk usage changes constantly, so the ID only covers the filters and who the users are
+	id, err := hashID(map[string]interface{}{
+		"type":      userType,
+		"server":    server,
+		"usernames": usernames,
+	})
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	d.SetId(id)
+
+	return diags
+}
@@ -0,0 +1,117 @@
+package dreamhost
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"fmt"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	dkimVersion     = "v=DKIM1"
+	dkimRecordLabel = "_domainkey"
+
+	dkimAlgorithmRSA     = "rsa"
+	dkimAlgorithmEd25519 = "ed25519"
+)
+
+// dkimKey is a generated DKIM keypair in its published and stored forms
+type dkimKey struct {
+	Algorithm     string
+	PublicKey     string
+	PrivateKeyPEM string
+}
+
+// generateDKIMKey creates a new keypair. The public key is encoded as RFC 6376
+// (RSA, DER SubjectPublicKeyInfo) or RFC 8463 (Ed25519, raw key) expect it in
+// the p= tag, the private key as a PKCS#8 PEM block.
+func generateDKIMKey(algorithm string, rsaBits int) (*dkimKey, error) {
+	var (
+		publicKey  []byte
+		privateKey interface{}
+		err        error
+	)
+
+	switch algorithm {
+	case dkimAlgorithmRSA:
+		key, genErr := rsa.GenerateKey(rand.Reader, rsaBits)
+		if genErr != nil {
+			return nil, errors.Wrap(genErr, "failed to generate RSA key")
+		}
+		privateKey = key
+		if publicKey, err = x509.MarshalPKIXPublicKey(&key.PublicKey); err != nil {
+			return nil, errors.Wrap(err, "failed to encode RSA public key")
+		}
+	case dkimAlgorithmEd25519:
+		public, private, genErr := ed25519.GenerateKey(rand.Reader)
+		if genErr != nil {
+			return nil, errors.Wrap(genErr, "failed to generate Ed25519 key")
+		}
+		privateKey = private
+		publicKey = public
+	default:
+		return nil, fmt.Errorf("unsupported DKIM key algorithm: %s", algorithm)
+	}
+
+	der, err := x509.MarshalPKCS8PrivateKey(privateKey)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to encode private key")
+	}
+
+	return &dkimKey{
+		Algorithm:     algorithm,
+		PublicKey:     base64.StdEncoding.EncodeToString(publicKey),
+		PrivateKeyPEM: string(pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der})),
+	}, nil
+}
+
+// String renders the public half of the key as a DKIM TXT record value
+func (k dkimKey) String() string {
+	return fmt.Sprintf("%s; k=%s; p=%s", dkimVersion, k.Algorithm, k.PublicKey)
+}
+
+// parseDKIMRecord extracts the key algorithm and public key from a DKIM TXT record value
+func parseDKIMRecord(value string) (*dkimKey, error) {
+	key := &dkimKey{Algorithm: dkimAlgorithmRSA}
+	found := false
+
+	for _, tag := range strings.Split(strings.Trim(value, `"`), ";") {
+		parts := strings.SplitN(strings.TrimSpace(tag), "=", 2)
+		if len(parts) != 2 {
+			continue
+		}
+		switch strings.ToLower(strings.TrimSpace(parts[0])) {
+		case "k":
+			key.Algorithm = strings.ToLower(strings.TrimSpace(parts[1]))
+		case "p":
+			// long keys may come back split into several quoted strings
+			key.PublicKey = strings.NewReplacer(`"`, "", " ", "").Replace(parts[1])
+			found = true
+		}
+	}
+
+	if !found {
+		return nil, fmt.Errorf("not a DKIM key record: %q", value)
+	}
+	return key, nil
+}
+
+// dkimRecordName returns the name the DKIM key of a selector is published at
+func dkimRecordName(selector, domain string) string {
+	return selector + "." + dkimRecordLabel + "." + strings.TrimSuffix(domain, ".")
+}
+
+// splitDKIMRecordName is the inverse of dkimRecordName
+func splitDKIMRecordName(name string) (string, string, error) {
+	parts := strings.SplitN(name, "."+dkimRecordLabel+".", 2)
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		return "", "", fmt.Errorf("DKIM record must be published at <selector>.%s.<domain>, got %s", dkimRecordLabel, name)
+	}
+	return parts[0], parts[1], nil
+}
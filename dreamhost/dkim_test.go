@@ -0,0 +1,113 @@
+package dreamhost
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"testing"
+
+	dreamhostapi "github.com/adamantal/go-dreamhost/api"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGenerateDKIMKey(t *testing.T) {
+	t.Parallel()
+
+	t.Run("ed25519", func(t *testing.T) {
+		t.Parallel()
+
+		key, err := generateDKIMKey(dkimAlgorithmEd25519, 0)
+		require.NoError(t, err)
+
+		block, _ := pem.Decode([]byte(key.PrivateKeyPEM))
+		require.NotNil(t, block)
+		private, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+		require.NoError(t, err)
+
+		public, ok := private.(ed25519.PrivateKey).Public().(ed25519.PublicKey)
+		require.True(t, ok)
+		assert.Equal(t, base64.StdEncoding.EncodeToString(public), key.PublicKey)
+		assert.Equal(t, "v=DKIM1; k=ed25519; p="+key.PublicKey, key.String())
+	})
+
+	t.Run("rsa", func(t *testing.T) {
+		t.Parallel()
+
+		key, err := generateDKIMKey(dkimAlgorithmRSA, 1024)
+		require.NoError(t, err)
+
+		der, err := base64.StdEncoding.DecodeString(key.PublicKey)
+		require.NoError(t, err)
+		public, err := x509.ParsePKIXPublicKey(der)
+		require.NoError(t, err)
+		assert.Equal(t, 1024, public.(*rsa.PublicKey).N.BitLen())
+	})
+
+	t.Run("unsupported", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := generateDKIMKey("dsa", 0)
+		assert.Error(t, err)
+	})
+}
+
+func TestParseDKIMRecord(t *testing.T) {
+	t.Parallel()
+
+	key, err := parseDKIMRecord(`v=DKIM1; k=ed25519; p=11qYAYKxCrfVS/7TyWQHOg7hcvPapiMlrwIaaPcHURo=`)
+	require.NoError(t, err)
+	assert.Equal(t, dkimAlgorithmEd25519, key.Algorithm)
+	assert.Equal(t, "11qYAYKxCrfVS/7TyWQHOg7hcvPapiMlrwIaaPcHURo=", key.PublicKey)
+
+	key, err = parseDKIMRecord(`"v=DKIM1; p=MIGfMA0" "GCSqGSIb3"`)
+	require.NoError(t, err)
+	assert.Equal(t, dkimAlgorithmRSA, key.Algorithm)
+	assert.Equal(t, "MIGfMA0GCSqGSIb3", key.PublicKey)
+
+	_, err = parseDKIMRecord("v=spf1 -all")
+	assert.Error(t, err)
+}
+
+func TestDKIMRecordName(t *testing.T) {
+	t.Parallel()
+
+	name := dkimRecordName("s2024", "example.com.")
+	assert.Equal(t, "s2024._domainkey.example.com", name)
+
+	selector, domain, err := splitDKIMRecordName(name)
+	require.NoError(t, err)
+	assert.Equal(t, "s2024", selector)
+	assert.Equal(t, "example.com", domain)
+
+	_, _, err = splitDKIMRecordName("_dmarc.example.com")
+	assert.Error(t, err)
+}
+
+func TestResourceDNSDKIMKeyReadLongValue(t *testing.T) {
+	t.Parallel()
+
+	// the value of a 2048-bit RSA key is longer than a TXT string, yet it is the
+	// one this resource generates, so refreshing it does not warn
+	key, err := generateDKIMKey(dkimAlgorithmRSA, defaultDKIMRSABits)
+	require.NoError(t, err)
+	require.Greater(t, len(key.String()), maxTXTStringLength)
+
+	record := dreamhostapi.DNSRecord{
+		Record: dkimRecordName("s2024", "example.com"),
+		Type:   dreamhostapi.TXTRecordType,
+		Value:  key.String(),
+	}
+	mock := NewMockDreamhostClient()
+	mock.SetRecords([]dreamhostapi.DNSRecord{record})
+
+	d := schema.TestResourceDataRaw(t, resourceDNSDKIMKey().Schema, map[string]interface{}{})
+	d.SetId(recordInputToID(dreamhostapi.DNSRecordInput{Record: record.Record, Type: record.Type, Value: record.Value}))
+	diags := resourceDNSDKIMKeyRead(context.Background(), d, newDreamhostClient(mock))
+	assert.Empty(t, diags)
+	assert.Equal(t, key.PublicKey, d.Get("public_key"))
+}
@@ -0,0 +1,201 @@
+package dreamhost
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	dreamhostapi "github.com/adamantal/go-dreamhost/api"
+)
+
+const (
+	dmarcVersion     = "v=DMARC1"
+	dmarcRecordLabel = "_dmarc"
+
+	// defaults from RFC 7489 section 6.3, tags holding them are not rendered
+	dmarcDefaultPct       = 100
+	dmarcDefaultAlignment = "r"
+	dmarcDefaultFailure   = "0"
+)
+
+// nolint:gochecknoglobals
+var (
+	// dmarcModeledTags are the tags with their own policy fields, everything else
+	// is carried in Extra
+	dmarcModeledTags = []string{"v", "p", "sp", "pct", "rua", "ruf", "adkim", "aspf", "fo"}
+
+	dmarcTagSeparator = regexp.MustCompile(`\s*=\s*`)
+	dmarcTagStart     = regexp.MustCompile(`^[A-Za-z][A-Za-z0-9]*=`)
+)
+
+// dmarcPolicy is the structured form of a DMARC TXT record value
+type dmarcPolicy struct {
+	Policy          string
+	SubdomainPolicy string
+	Pct             int
+	RUA             []string
+	RUF             []string
+	ADKIM           string
+	ASPF            string
+	FO              string
+	// Extra holds the tags without a field of their own, such as rf and ri
+	Extra map[string]string
+}
+
+// newDMARCPolicy returns a policy with the RFC 7489 defaults applied
+func newDMARCPolicy() *dmarcPolicy {
+	return &dmarcPolicy{
+		Pct:   dmarcDefaultPct,
+		ADKIM: dmarcDefaultAlignment,
+		ASPF:  dmarcDefaultAlignment,
+		FO:    dmarcDefaultFailure,
+	}
+}
+
+// String renders the policy as a TXT record value, in the tag order of RFC 7489
+func (p dmarcPolicy) String() string {
+	tags := []string{dmarcVersion, "p=" + p.Policy}
+	if p.SubdomainPolicy != "" {
+		tags = append(tags, "sp="+p.SubdomainPolicy)
+	}
+	if p.Pct != dmarcDefaultPct {
+		tags = append(tags, "pct="+strconv.Itoa(p.Pct))
+	}
+	if len(p.RUA) > 0 {
+		tags = append(tags, "rua="+strings.Join(p.RUA, ","))
+	}
+	if len(p.RUF) > 0 {
+		tags = append(tags, "ruf="+strings.Join(p.RUF, ","))
+	}
+	if p.ADKIM != "" && p.ADKIM != dmarcDefaultAlignment {
+		tags = append(tags, "adkim="+p.ADKIM)
+	}
+	if p.ASPF != "" && p.ASPF != dmarcDefaultAlignment {
+		tags = append(tags, "aspf="+p.ASPF)
+	}
+	if p.FO != "" && p.FO != dmarcDefaultFailure {
+		tags = append(tags, "fo="+p.FO)
+	}
+	extra := make([]string, 0, len(p.Extra))
+	for key := range p.Extra {
+		extra = append(extra, key)
+	}
+	sort.Strings(extra)
+	for _, key := range extra {
+		tags = append(tags, key+"="+p.Extra[key])
+	}
+	return strings.Join(tags, "; ")
+}
+
+// isDMARCValue checks whether a TXT record value is a DMARC policy
+func isDMARCValue(value string) bool {
+	value = strings.TrimSpace(strings.Trim(value, `"`))
+	return strings.HasPrefix(value, dmarcVersion) &&
+		(len(value) == len(dmarcVersion) || value[len(dmarcVersion)] == ';' || value[len(dmarcVersion)] == ' ')
+}
+
+// parseDMARCPolicy parses a TXT record value into its structured form
+func parseDMARCPolicy(value string) (*dmarcPolicy, error) {
+	if !isDMARCValue(value) {
+		return nil, fmt.Errorf("not a DMARC record: %q", value)
+	}
+
+	tags, err := splitDMARCTags(value)
+	if err != nil {
+		return nil, err
+	}
+
+	policy := newDMARCPolicy()
+	// the first tag is the version checked above
+	for _, tag := range tags[1:] {
+		key, val := strings.ToLower(tag[0]), tag[1]
+
+		switch key {
+		case "p":
+			policy.Policy = strings.ToLower(val)
+		case "sp":
+			policy.SubdomainPolicy = strings.ToLower(val)
+		case "pct":
+			pct, err := strconv.Atoi(val)
+			if err != nil {
+				return nil, fmt.Errorf("DMARC pct must be a number, got: %s", val)
+			}
+			policy.Pct = pct
+		case "rua":
+			policy.RUA = splitDMARCURIs(val)
+		case "ruf":
+			policy.RUF = splitDMARCURIs(val)
+		case "adkim":
+			policy.ADKIM = strings.ToLower(val)
+		case "aspf":
+			policy.ASPF = strings.ToLower(val)
+		case "fo":
+			policy.FO = strings.ToLower(val)
+		case "v":
+		default:
+			// rf, ri and future extensions are kept as they are
+			if policy.Extra == nil {
+				policy.Extra = map[string]string{}
+			}
+			policy.Extra[key] = val
+		}
+	}
+
+	if policy.Policy == "" {
+		return nil, fmt.Errorf("DMARC record is missing the required p tag: %q", value)
+	}
+
+	return policy, nil
+}
+
+// splitDMARCTags splits a DMARC record value into key and value pairs. Tags are
+// separated by semicolons, but records only separating them by whitespace are
+// accepted as well.
+func splitDMARCTags(value string) ([][2]string, error) {
+	var tags [][2]string
+	for _, segment := range strings.Split(strings.Trim(strings.TrimSpace(value), `"`), ";") {
+		segment = dmarcTagSeparator.ReplaceAllString(strings.TrimSpace(segment), "=")
+		if segment == "" {
+			continue
+		}
+
+		var current []string
+		for _, field := range strings.Fields(segment) {
+			if dmarcTagStart.MatchString(field) {
+				current = append(current, field)
+				continue
+			}
+			if len(current) == 0 {
+				return nil, fmt.Errorf("malformed DMARC tag %q in %q", segment, value)
+			}
+			// a value continued after whitespace, such as a URI list with spaces
+			current[len(current)-1] += field
+		}
+		for _, tag := range current {
+			parts := strings.SplitN(tag, "=", 2)
+			tags = append(tags, [2]string{parts[0], parts[1]})
+		}
+	}
+	return tags, nil
+}
+
+func splitDMARCURIs(value string) []string {
+	uris := strings.Split(value, ",")
+	for i := range uris {
+		uris[i] = strings.TrimSpace(uris[i])
+	}
+	return uris
+}
+
+// dmarcRecordName returns the name the DMARC policy of a domain is published at
+func dmarcRecordName(domain string) string {
+	return dmarcRecordLabel + "." + strings.TrimSuffix(domain, ".")
+}
+
+// findDMARCRecords returns the DMARC TXT records published for domain, skipping
+// the record with the given resource ID
+func findDMARCRecords(records []dreamhostapi.DNSRecord, domain, skipID string) []dreamhostapi.DNSRecord {
+	return findTXTRecords(records, dmarcRecordName(domain), skipID, isDMARCValue)
+}
@@ -0,0 +1,136 @@
+package dreamhost
+
+import (
+	"testing"
+
+	dreamhostapi "github.com/adamantal/go-dreamhost/api"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDMARCPolicy_String(t *testing.T) {
+	t.Parallel()
+
+	minimal := newDMARCPolicy()
+	minimal.Policy = "none"
+	assert.Equal(t, "v=DMARC1; p=none", minimal.String())
+
+	full := dmarcPolicy{
+		Policy:          "reject",
+		SubdomainPolicy: "quarantine",
+		Pct:             50,
+		RUA:             []string{"mailto:dmarc@example.com", "mailto:reports@example.net!10m"},
+		RUF:             []string{"mailto:forensics@example.com"},
+		ADKIM:           "s",
+		ASPF:            "s",
+		FO:              "1:d",
+	}
+	assert.Equal(t,
+		"v=DMARC1; p=reject; sp=quarantine; pct=50; rua=mailto:dmarc@example.com,mailto:reports@example.net!10m; "+
+			"ruf=mailto:forensics@example.com; adkim=s; aspf=s; fo=1:d",
+		full.String(),
+	)
+}
+
+func TestParseDMARCPolicy(t *testing.T) {
+	t.Parallel()
+
+	t.Run("round_trip", func(t *testing.T) {
+		t.Parallel()
+
+		value := "v=DMARC1; p=quarantine; pct=25; rua=mailto:a@example.com,mailto:b@example.com; aspf=s"
+		policy, err := parseDMARCPolicy(value)
+		require.NoError(t, err)
+
+		assert.Equal(t, "quarantine", policy.Policy)
+		assert.Equal(t, 25, policy.Pct)
+		assert.Equal(t, []string{"mailto:a@example.com", "mailto:b@example.com"}, policy.RUA)
+		assert.Equal(t, "r", policy.ADKIM)
+		assert.Equal(t, "s", policy.ASPF)
+		assert.Equal(t, value, policy.String())
+	})
+
+	t.Run("defaults_and_whitespace", func(t *testing.T) {
+		t.Parallel()
+
+		policy, err := parseDMARCPolicy(`"v=DMARC1;p=NONE ;  adkim = r;"`)
+		require.NoError(t, err)
+		assert.Equal(t, "none", policy.Policy)
+		assert.Equal(t, dmarcDefaultPct, policy.Pct)
+		assert.Equal(t, "0", policy.FO)
+	})
+
+	t.Run("reporting_tags_are_kept", func(t *testing.T) {
+		t.Parallel()
+
+		policy, err := parseDMARCPolicy("v=DMARC1; p=reject; rf=afrf; ri=86400")
+		require.NoError(t, err)
+		assert.Equal(t, "reject", policy.Policy)
+		assert.Equal(t, map[string]string{"rf": "afrf", "ri": "86400"}, policy.Extra)
+		assert.Equal(t, "v=DMARC1; p=reject; rf=afrf; ri=86400", policy.String())
+	})
+
+	t.Run("whitespace_separated_tags", func(t *testing.T) {
+		t.Parallel()
+
+		for _, value := range []string{
+			"v=DMARC1 p=quarantine pct=10",
+			"v=DMARC1  ;\tp = quarantine;pct=10 ;",
+			" v=DMARC1; p=quarantine; pct=10 ",
+		} {
+			policy, err := parseDMARCPolicy(value)
+			require.NoError(t, err, value)
+			assert.Equal(t, "quarantine", policy.Policy, value)
+			assert.Equal(t, 10, policy.Pct, value)
+			assert.Empty(t, policy.Extra, value)
+		}
+
+		policy, err := parseDMARCPolicy("v=DMARC1; p=none; rua=mailto:a@example.com, mailto:b@example.com")
+		require.NoError(t, err)
+		assert.Equal(t, []string{"mailto:a@example.com", "mailto:b@example.com"}, policy.RUA)
+	})
+
+	tests := []struct {
+		name  string
+		value string
+	}{
+		{"not_dmarc", "v=spf1 -all"},
+		{"missing_policy", "v=DMARC1; pct=10"},
+		{"bad_pct", "v=DMARC1; p=none; pct=all"},
+		{"malformed_tag", "v=DMARC1; p=none; rua"},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			_, err := parseDMARCPolicy(tt.value)
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestFindDMARCRecords(t *testing.T) {
+	t.Parallel()
+
+	records := []dreamhostapi.DNSRecord{
+		{Record: "_dmarc.example.com", Type: dreamhostapi.TXTRecordType, Value: "v=DMARC1; p=none"},
+		{Record: "_dmarc.example.com", Type: dreamhostapi.TXTRecordType, Value: "unrelated"},
+		{Record: "example.com", Type: dreamhostapi.TXTRecordType, Value: "v=DMARC1; p=none"},
+	}
+
+	found := findDMARCRecords(records, "example.com.", "")
+	require.Len(t, found, 1)
+	assert.Equal(t, "_dmarc.example.com", found[0].Record)
+	assert.Empty(t, findDMARCRecords(records, "example.net", ""))
+}
+
+func TestValidateDMARCExtraTags(t *testing.T) {
+	t.Parallel()
+
+	_, errs := validateDMARCExtraTags(map[string]interface{}{"rf": "afrf", "ri": "86400"}, "extra_tags")
+	assert.Empty(t, errs)
+
+	_, errs = validateDMARCExtraTags(map[string]interface{}{"pct": "10", "not a tag": "x"}, "extra_tags")
+	assert.Len(t, errs, 2)
+}
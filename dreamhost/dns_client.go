@@ -0,0 +1,154 @@
+package dreamhost
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"net"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"golang.org/x/net/dns/dnsmessage"
+)
+
+const (
+	dnsPort         = "53"
+	dnsQueryTimeout = 5 * time.Second
+	maxDNSMessage   = 65535
+
+	dnsNetworkUDP = "udp"
+	dnsNetworkTCP = "tcp"
+)
+
+// dnsResponse is the part of a DNS answer the provider looks at
+type dnsResponse struct {
+	RCode         dnsmessage.RCode
+	Authoritative bool
+	Answers       []dnsmessage.Resource
+}
+
+// nameserverAddress appends the default DNS port to a nameserver given without one
+func nameserverAddress(server string) string {
+	if _, _, err := net.SplitHostPort(server); err == nil {
+		return server
+	}
+	return net.JoinHostPort(strings.Trim(server, "[]"), dnsPort)
+}
+
+// queryDNS sends a single question to a nameserver over UDP or TCP. A truncated
+// UDP answer is retried over TCP.
+func queryDNS(
+	ctx context.Context, server, network, name string, qtype dnsmessage.Type, recursionDesired bool,
+) (*dnsResponse, error) {
+	qname, err := dnsmessage.NewName(dnsFQDN(name))
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid DNS name %s", name)
+	}
+
+	var id [2]byte
+	if _, err := rand.Read(id[:]); err != nil {
+		return nil, errors.Wrap(err, "failed to generate DNS query ID")
+	}
+	query := dnsmessage.Message{
+		Header: dnsmessage.Header{ID: binary.BigEndian.Uint16(id[:]), RecursionDesired: recursionDesired},
+		Questions: []dnsmessage.Question{
+			{Name: qname, Type: qtype, Class: dnsmessage.ClassINET},
+		},
+	}
+	packed, err := query.Pack()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to build DNS query")
+	}
+
+	answer, err := exchangeDNS(ctx, nameserverAddress(server), network, packed)
+	if err != nil {
+		return nil, errors.Wrapf(err, "DNS query for %s %s to %s over %s failed", name, qtype, server, network)
+	}
+
+	var msg dnsmessage.Message
+	if err := msg.Unpack(answer); err != nil {
+		return nil, errors.Wrapf(err, "failed to parse DNS answer from %s", server)
+	}
+	if msg.ID != query.ID {
+		return nil, fmt.Errorf("DNS answer from %s does not match the query", server)
+	}
+	if msg.Truncated && network == dnsNetworkUDP {
+		return queryDNS(ctx, server, dnsNetworkTCP, name, qtype, recursionDesired)
+	}
+
+	return &dnsResponse{
+		RCode:         msg.RCode,
+		Authoritative: msg.Authoritative,
+		Answers:       msg.Answers,
+	}, nil
+}
+
+func exchangeDNS(ctx context.Context, address, network string, query []byte) ([]byte, error) {
+	// every query gets its own timeout; the operation timeouts of the SDK are far
+	// too long to wait for a UDP answer that was dropped
+	ctx, cancel := context.WithTimeout(ctx, dnsQueryTimeout)
+	defer cancel()
+
+	var dialer net.Dialer
+	conn, err := dialer.DialContext(ctx, network, address)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+
+	if deadline, ok := ctx.Deadline(); ok {
+		if err := conn.SetDeadline(deadline); err != nil {
+			return nil, err
+		}
+	}
+
+	if network == dnsNetworkUDP {
+		if _, err := conn.Write(query); err != nil {
+			return nil, err
+		}
+		buf := make([]byte, maxDNSMessage)
+		n, err := conn.Read(buf)
+		if err != nil {
+			return nil, err
+		}
+		return buf[:n], nil
+	}
+
+	// DNS over TCP prefixes every message with its length
+	framed := make([]byte, 2+len(query))
+	binary.BigEndian.PutUint16(framed, uint16(len(query)))
+	copy(framed[2:], query)
+	if _, err := conn.Write(framed); err != nil {
+		return nil, err
+	}
+	var length [2]byte
+	if _, err := io.ReadFull(conn, length[:]); err != nil {
+		return nil, err
+	}
+	answer := make([]byte, binary.BigEndian.Uint16(length[:]))
+	if _, err := io.ReadFull(conn, answer); err != nil {
+		return nil, err
+	}
+	return answer, nil
+}
+
+// txtAnswers returns the TXT values in a DNS answer, joining multi-string records
+func txtAnswers(resp *dnsResponse) []string {
+	var values []string
+	for _, answer := range resp.Answers {
+		if txt, ok := answer.Body.(*dnsmessage.TXTResource); ok {
+			values = append(values, strings.Join(txt.TXT, ""))
+		}
+	}
+	return values
+}
+
+func dnsFQDN(name string) string {
+	if strings.HasSuffix(name, ".") {
+		return name
+	}
+	return name + "."
+}
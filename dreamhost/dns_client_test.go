@@ -0,0 +1,206 @@
+package dreamhost
+
+import (
+	"context"
+	"encoding/binary"
+	"io"
+	"net"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/net/dns/dnsmessage"
+)
+
+// testDNSServer is an in-process authoritative nameserver serving static records over UDP and TCP
+type testDNSServer struct {
+	mu      sync.Mutex
+	records map[string][]dnsmessage.Resource
+
+	udp  net.PacketConn
+	tcp  net.Listener
+	Addr string
+}
+
+// startTestDNSServer starts a nameserver on a random local port, stopped when the test ends
+func startTestDNSServer(t *testing.T) *testDNSServer {
+	t.Helper()
+
+	server := &testDNSServer{records: map[string][]dnsmessage.Resource{}}
+	for attempt := 0; ; attempt++ {
+		udp, err := net.ListenPacket("udp", "127.0.0.1:0")
+		require.NoError(t, err)
+		tcp, err := net.Listen("tcp", udp.LocalAddr().String())
+		if err != nil && attempt < 10 {
+			udp.Close()
+			continue
+		}
+		require.NoError(t, err)
+		server.udp, server.tcp, server.Addr = udp, tcp, udp.LocalAddr().String()
+		break
+	}
+
+	go server.serveUDP()
+	go server.serveTCP()
+	t.Cleanup(func() {
+		server.udp.Close()
+		server.tcp.Close()
+	})
+
+	return server
+}
+
+// Add serves an additional answer for the name and type of the resource
+func (s *testDNSServer) Add(name string, qtype dnsmessage.Type, ttl uint32, body dnsmessage.ResourceBody) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	key := strings.ToLower(dnsFQDN(name)) + "/" + qtype.String()
+	s.records[key] = append(s.records[key], dnsmessage.Resource{
+		Header: dnsmessage.ResourceHeader{
+			Name:  dnsmessage.MustNewName(dnsFQDN(name)),
+			Type:  qtype,
+			Class: dnsmessage.ClassINET,
+			TTL:   ttl,
+		},
+		Body: body,
+	})
+}
+
+// AddTXT serves an additional TXT record
+func (s *testDNSServer) AddTXT(name string, values ...string) {
+	s.Add(name, dnsmessage.TypeTXT, 300, &dnsmessage.TXTResource{TXT: values})
+}
+
+func (s *testDNSServer) answer(query []byte) []byte {
+	var msg dnsmessage.Message
+	if err := msg.Unpack(query); err != nil || len(msg.Questions) != 1 {
+		return nil
+	}
+	question := msg.Questions[0]
+
+	s.mu.Lock()
+	key := strings.ToLower(question.Name.String()) + "/" + question.Type.String()
+	answers := append([]dnsmessage.Resource{}, s.records[key]...)
+	s.mu.Unlock()
+
+	msg.Response = true
+	msg.Authoritative = true
+	msg.Answers = answers
+	if len(answers) == 0 {
+		msg.RCode = dnsmessage.RCodeNameError
+	}
+	packed, err := msg.Pack()
+	if err != nil {
+		return nil
+	}
+	return packed
+}
+
+func (s *testDNSServer) serveUDP() {
+	buf := make([]byte, maxDNSMessage)
+	for {
+		n, addr, err := s.udp.ReadFrom(buf)
+		if err != nil {
+			return
+		}
+		if answer := s.answer(buf[:n]); answer != nil {
+			_, _ = s.udp.WriteTo(answer, addr)
+		}
+	}
+}
+
+func (s *testDNSServer) serveTCP() {
+	for {
+		conn, err := s.tcp.Accept()
+		if err != nil {
+			return
+		}
+		go func() {
+			defer conn.Close()
+			var length [2]byte
+			if _, err := io.ReadFull(conn, length[:]); err != nil {
+				return
+			}
+			query := make([]byte, binary.BigEndian.Uint16(length[:]))
+			if _, err := io.ReadFull(conn, query); err != nil {
+				return
+			}
+			answer := s.answer(query)
+			framed := make([]byte, 2+len(answer))
+			binary.BigEndian.PutUint16(framed, uint16(len(answer)))
+			copy(framed[2:], answer)
+			_, _ = conn.Write(framed)
+		}()
+	}
+}
+
+func TestQueryDNS(t *testing.T) {
+	t.Parallel()
+
+	server := startTestDNSServer(t)
+	server.AddTXT("_acme-challenge.example.com", "token-part-1", "token-part-2")
+
+	for _, network := range []string{dnsNetworkUDP, dnsNetworkTCP} {
+		network := network
+		t.Run(network, func(t *testing.T) {
+			t.Parallel()
+
+			resp, err := queryDNS(context.Background(), server.Addr, network,
+				"_acme-challenge.example.com", dnsmessage.TypeTXT, false)
+			require.NoError(t, err)
+			assert.True(t, resp.Authoritative)
+			assert.Equal(t, []string{"token-part-1token-part-2"}, txtAnswers(resp))
+		})
+	}
+
+	t.Run("missing_name", func(t *testing.T) {
+		t.Parallel()
+
+		resp, err := queryDNS(context.Background(), server.Addr, dnsNetworkUDP,
+			"missing.example.com.", dnsmessage.TypeTXT, false)
+		require.NoError(t, err)
+		assert.Equal(t, dnsmessage.RCodeNameError, resp.RCode)
+		assert.Empty(t, txtAnswers(resp))
+	})
+
+	t.Run("unreachable_server", func(t *testing.T) {
+		t.Parallel()
+
+		ctx, cancel := context.WithTimeout(context.Background(), 200*time.Millisecond)
+		defer cancel()
+
+		_, err := queryDNS(ctx, "127.0.0.1:1", dnsNetworkTCP, "example.com", dnsmessage.TypeTXT, false)
+		assert.Error(t, err)
+	})
+
+	t.Run("dropped_answer", func(t *testing.T) {
+		t.Parallel()
+
+		// a nameserver that never answers
+		silent, err := net.ListenPacket("udp", "127.0.0.1:0")
+		require.NoError(t, err)
+		defer silent.Close()
+
+		// the query gives up after its own timeout, long before the operation times out
+		ctx, cancel := context.WithTimeout(context.Background(), time.Hour)
+		defer cancel()
+
+		start := time.Now()
+		_, err = queryDNS(ctx, silent.LocalAddr().String(), dnsNetworkUDP, "example.com", dnsmessage.TypeTXT, false)
+		assert.Error(t, err)
+		assert.Less(t, time.Since(start), 2*dnsQueryTimeout)
+	})
+}
+
+func TestNameserverAddress(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, "ns1.dreamhost.com:53", nameserverAddress("ns1.dreamhost.com"))
+	assert.Equal(t, "127.0.0.1:5353", nameserverAddress("127.0.0.1:5353"))
+	assert.Equal(t, "[2001:db8::1]:53", nameserverAddress("2001:db8::1"))
+	assert.Equal(t, "[2001:db8::1]:53", nameserverAddress("[2001:db8::1]"))
+}
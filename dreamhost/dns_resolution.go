@@ -0,0 +1,174 @@
+package dreamhost
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"sort"
+	"strings"
+
+	dreamhostapi "github.com/adamantal/go-dreamhost/api"
+	"golang.org/x/net/dns/dnsmessage"
+)
+
+// resolvableTypes are the record types the resolution data source can query
+// nolint:gochecknoglobals
+var resolvableTypes = map[string]dnsmessage.Type{
+	"A":     dnsmessage.TypeA,
+	"AAAA":  dnsmessage.TypeAAAA,
+	"CNAME": dnsmessage.TypeCNAME,
+	"MX":    dnsmessage.TypeMX,
+	"NS":    dnsmessage.TypeNS,
+	"PTR":   dnsmessage.TypePTR,
+	"SRV":   dnsmessage.TypeSRV,
+	"TXT":   dnsmessage.TypeTXT,
+}
+
+// dnsResolution is the answer of a single nameserver, or the error querying it
+type dnsResolution struct {
+	Nameserver    string
+	RCode         string
+	Authoritative bool
+	Values        []string
+	Error         string
+}
+
+// resolveDNS queries every nameserver for the name and type; a nameserver that
+// cannot be queried is reported in its resolution rather than failing the others
+func resolveDNS(
+	ctx context.Context, nameservers []string, network, name, recordType string, recursionDesired bool,
+) ([]dnsResolution, error) {
+	qtype, ok := resolvableTypes[recordType]
+	if !ok {
+		return nil, fmt.Errorf("unsupported record type for resolution: %s", recordType)
+	}
+
+	resolutions := make([]dnsResolution, 0, len(nameservers))
+	for _, nameserver := range nameservers {
+		resp, err := queryDNS(ctx, nameserver, network, name, qtype, recursionDesired)
+		if err != nil {
+			resolutions = append(resolutions, dnsResolution{
+				Nameserver: nameserver,
+				Values:     []string{},
+				Error:      err.Error(),
+			})
+			continue
+		}
+
+		values := answerValues(resp, qtype)
+		sort.Strings(values)
+		resolutions = append(resolutions, dnsResolution{
+			Nameserver:    nameserver,
+			RCode:         rcodeName(resp.RCode),
+			Authoritative: resp.Authoritative,
+			Values:        values,
+		})
+	}
+
+	return resolutions, nil
+}
+
+// answerValues renders the answers of the queried type in the normalized form
+// of normalizeRecordValue, skipping e.g. the CNAME chain leading to them
+func answerValues(resp *dnsResponse, qtype dnsmessage.Type) []string {
+	values := []string{}
+	for _, answer := range resp.Answers {
+		if answer.Header.Type != qtype {
+			continue
+		}
+
+		var value string
+		switch body := answer.Body.(type) {
+		case *dnsmessage.AResource:
+			value = net.IP(body.A[:]).String()
+		case *dnsmessage.AAAAResource:
+			value = net.IP(body.AAAA[:]).String()
+		case *dnsmessage.CNAMEResource:
+			value = normalizeDNSName(body.CNAME.String())
+		case *dnsmessage.NSResource:
+			value = normalizeDNSName(body.NS.String())
+		case *dnsmessage.PTRResource:
+			value = normalizeDNSName(body.PTR.String())
+		case *dnsmessage.MXResource:
+			value = fmt.Sprintf("%d %s", body.Pref, normalizeDNSName(body.MX.String()))
+		case *dnsmessage.SRVResource:
+			value = fmt.Sprintf("%d %d %d %s", body.Priority, body.Weight, body.Port, normalizeDNSName(body.Target.String()))
+		case *dnsmessage.TXTResource:
+			value = strings.Join(body.TXT, "")
+		default:
+			continue
+		}
+		values = append(values, value)
+	}
+	return values
+}
+
+// normalizeRecordValue brings an API record value into the form answerValues
+// renders DNS answers in, so both can be compared
+func normalizeRecordValue(recordType, value string) string {
+	switch recordType {
+	case "A", "AAAA":
+		if ip := net.ParseIP(value); ip != nil {
+			return ip.String()
+		}
+	case "CNAME", "NS", "PTR":
+		return normalizeDNSName(value)
+	case "MX":
+		if mx, err := parseMXValue(value); err == nil {
+			return fmt.Sprintf("%d %s", mx.Priority, normalizeDNSName(mx.Exchange))
+		}
+	case "SRV":
+		if srv, err := parseSRVValue(value); err == nil {
+			return fmt.Sprintf("%d %d %d %s", srv.Priority, srv.Weight, srv.Port, normalizeDNSName(srv.Target))
+		}
+	}
+	return value
+}
+
+func normalizeDNSName(name string) string {
+	return strings.ToLower(strings.TrimSuffix(name, "."))
+}
+
+// apiRecordValues returns the sorted, normalized values the account listing has for the name and type
+func apiRecordValues(records []dreamhostapi.DNSRecord, name, recordType string) []string {
+	values := []string{}
+	for _, record := range records {
+		if string(record.Type) == recordType && dnsRecordFQDN(record.Record) == dnsRecordFQDN(name) {
+			values = append(values, normalizeRecordValue(recordType, record.Value))
+		}
+	}
+	sort.Strings(values)
+	return values
+}
+
+// sameValues compares two sorted value lists
+func sameValues(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func rcodeName(rcode dnsmessage.RCode) string {
+	switch rcode {
+	case dnsmessage.RCodeSuccess:
+		return "NOERROR"
+	case dnsmessage.RCodeFormatError:
+		return "FORMERR"
+	case dnsmessage.RCodeServerFailure:
+		return "SERVFAIL"
+	case dnsmessage.RCodeNameError:
+		return "NXDOMAIN"
+	case dnsmessage.RCodeNotImplemented:
+		return "NOTIMP"
+	case dnsmessage.RCodeRefused:
+		return "REFUSED"
+	default:
+		return rcode.String()
+	}
+}
@@ -0,0 +1,121 @@
+package dreamhost
+
+import (
+	"context"
+	"testing"
+
+	dreamhostapi "github.com/adamantal/go-dreamhost/api"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/net/dns/dnsmessage"
+)
+
+func TestResolveDNS(t *testing.T) {
+	t.Parallel()
+
+	server := startTestDNSServer(t)
+	server.Add("www.example.com", dnsmessage.TypeCNAME, 300,
+		&dnsmessage.CNAMEResource{CNAME: dnsmessage.MustNewName("example.com.")})
+	server.Add("example.com", dnsmessage.TypeMX, 300,
+		&dnsmessage.MXResource{Pref: 20, MX: dnsmessage.MustNewName("MX2.example.com.")})
+	server.Add("example.com", dnsmessage.TypeMX, 300,
+		&dnsmessage.MXResource{Pref: 10, MX: dnsmessage.MustNewName("mx1.example.com.")})
+
+	for _, network := range []string{dnsNetworkUDP, dnsNetworkTCP} {
+		resolutions, err := resolveDNS(context.Background(), []string{server.Addr}, network, "example.com", "MX", false)
+		require.NoError(t, err)
+		require.Len(t, resolutions, 1)
+		assert.Equal(t, "NOERROR", resolutions[0].RCode)
+		assert.True(t, resolutions[0].Authoritative)
+		assert.Equal(t, []string{"10 mx1.example.com", "20 mx2.example.com"}, resolutions[0].Values)
+	}
+
+	resolutions, err := resolveDNS(context.Background(), []string{server.Addr}, dnsNetworkUDP, "www.example.com", "CNAME", false)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"example.com"}, resolutions[0].Values)
+
+	resolutions, err = resolveDNS(context.Background(), []string{server.Addr}, dnsNetworkUDP, "missing.example.com", "A", false)
+	require.NoError(t, err)
+	assert.Equal(t, "NXDOMAIN", resolutions[0].RCode)
+	assert.Empty(t, resolutions[0].Values)
+
+	_, err = resolveDNS(context.Background(), []string{server.Addr}, dnsNetworkUDP, "example.com", "NAPTR", false)
+	assert.Error(t, err)
+
+	// an unreachable nameserver is reported in its answer, the others are still queried
+	resolutions, err = resolveDNS(context.Background(), []string{"127.0.0.1:1", server.Addr}, dnsNetworkTCP,
+		"example.com", "MX", false)
+	require.NoError(t, err)
+	require.Len(t, resolutions, 2)
+	assert.Contains(t, resolutions[0].Error, "127.0.0.1:1")
+	assert.Empty(t, resolutions[0].Values)
+	assert.Empty(t, resolutions[1].Error)
+	assert.Equal(t, []string{"10 mx1.example.com", "20 mx2.example.com"}, resolutions[1].Values)
+}
+
+func TestNormalizeRecordValue(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, "2001:db8::1", normalizeRecordValue("AAAA", "2001:0db8:0:0::0001"))
+	assert.Equal(t, "target.example.com", normalizeRecordValue("CNAME", "Target.Example.com."))
+	assert.Equal(t, "10 mail.example.com", normalizeRecordValue("MX", "10  Mail.example.com."))
+	assert.Equal(t, "0 5 5060 sip.example.com", normalizeRecordValue("SRV", "0 5 5060 sip.example.com."))
+	assert.Equal(t, "Hello World", normalizeRecordValue("TXT", "Hello World"))
+}
+
+func TestDataSourceDNSResolutionRead(t *testing.T) {
+	t.Parallel()
+
+	server := startTestDNSServer(t)
+	server.Add("example.com", dnsmessage.TypeA, 300, &dnsmessage.AResource{A: [4]byte{192, 0, 2, 1}})
+	server.AddTXT("example.com", "v=spf1 ", "-all")
+
+	mock := NewMockDreamhostClient()
+	mock.SetRecords([]dreamhostapi.DNSRecord{
+		{Record: "example.com", Zone: "example.com", Type: dreamhostapi.ARecordType, Value: "192.0.2.1"},
+		{Record: "example.com", Zone: "example.com", Type: dreamhostapi.TXTRecordType, Value: "v=spf1 ~all"},
+	})
+	client := newDreamhostClient(mock)
+
+	tests := []struct {
+		recordType        string
+		values            []string
+		consistentWithAPI bool
+	}{
+		{"A", []string{"192.0.2.1"}, true},
+		{"TXT", []string{"v=spf1 -all"}, false},
+	}
+
+	for _, tt := range tests {
+		d := schema.TestResourceDataRaw(t, dataSourceDNSResolution().Schema, map[string]interface{}{
+			"name":        "example.com",
+			"type":        tt.recordType,
+			"nameservers": []interface{}{server.Addr, server.Addr},
+		})
+
+		diags := dataSourceDNSResolutionRead(context.Background(), d, client)
+		require.False(t, diags.HasError(), "%v", diags)
+
+		assert.Equal(t, tt.values, expandStringList(d.Get("values").([]interface{})))
+		assert.True(t, d.Get("consistent").(bool))
+		assert.Equal(t, tt.consistentWithAPI, d.Get("consistent_with_api").(bool))
+		assert.Equal(t, "NOERROR", d.Get("answers.1.rcode"))
+		assert.Empty(t, d.Get("answers.1.error"))
+		assert.NotEmpty(t, d.Id())
+	}
+
+	// one unreachable nameserver does not fail the data source
+	d := schema.TestResourceDataRaw(t, dataSourceDNSResolution().Schema, map[string]interface{}{
+		"name":        "example.com",
+		"type":        "A",
+		"protocol":    dnsNetworkTCP,
+		"nameservers": []interface{}{server.Addr, "127.0.0.1:1"},
+	})
+	diags := dataSourceDNSResolutionRead(context.Background(), d, client)
+	require.False(t, diags.HasError(), "%v", diags)
+	assert.Equal(t, []interface{}{"192.0.2.1"}, d.Get("values"))
+	assert.NotEmpty(t, d.Get("answers.1.error"))
+	assert.False(t, d.Get("consistent").(bool))
+	assert.False(t, d.Get("consistent_with_api").(bool))
+}
@@ -0,0 +1,78 @@
+package dreamhost
+
+import (
+	"sort"
+	"strings"
+
+	dreamhostapi "github.com/adamantal/go-dreamhost/api"
+)
+
+const (
+	dreamhostNameserverSuffix = ".dreamhost.com"
+)
+
+// dnsZone summarizes the records of a zone in the account listing
+type dnsZone struct {
+	Name             string
+	RecordCounts     map[string]int
+	RecordCount      int
+	EditableCount    int
+	NonEditableCount int
+	Nameservers      []string
+}
+
+// DreamHostNameservers reports whether the zone has apex NS records and all of
+// them point at DreamHost nameservers
+func (z dnsZone) DreamHostNameservers() bool {
+	if len(z.Nameservers) == 0 {
+		return false
+	}
+	for _, nameserver := range z.Nameservers {
+		if !strings.HasSuffix(nameserver, dreamhostNameserverSuffix) {
+			return false
+		}
+	}
+	return true
+}
+
+// summarizeDNSZones groups the records by zone, sorted by zone name
+func summarizeDNSZones(records []dreamhostapi.DNSRecord) []dnsZone {
+	zones := make(map[string]*dnsZone)
+
+	for _, record := range records {
+		name := dnsRecordFQDN(record.Zone)
+		if name == "" {
+			continue
+		}
+		zone, ok := zones[name]
+		if !ok {
+			zone = &dnsZone{Name: name, RecordCounts: make(map[string]int)}
+			zones[name] = zone
+		}
+
+		zone.RecordCount++
+		zone.RecordCounts[string(record.Type)]++
+		if record.Editable == dreamhostapi.Editable {
+			zone.EditableCount++
+		} else {
+			zone.NonEditableCount++
+		}
+
+		if record.Type == dreamhostapi.NSRecordType && dnsRecordFQDN(record.Record) == name {
+			nameserver := dnsRecordFQDN(record.Value)
+			if !containsString(zone.Nameservers, nameserver) {
+				zone.Nameservers = append(zone.Nameservers, nameserver)
+			}
+		}
+	}
+
+	result := make([]dnsZone, 0, len(zones))
+	for _, zone := range zones {
+		sort.Strings(zone.Nameservers)
+		result = append(result, *zone)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Name < result[j].Name
+	})
+	return result
+}
@@ -0,0 +1,45 @@
+package dreamhost
+
+import (
+	"testing"
+
+	dreamhostapi "github.com/adamantal/go-dreamhost/api"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSummarizeDNSZones(t *testing.T) {
+	t.Parallel()
+
+	records := []dreamhostapi.DNSRecord{
+		{Zone: "example.org", Record: "example.org", Type: dreamhostapi.NSRecordType, Value: "ns1.other.net.", Editable: dreamhostapi.Editable},
+		{Zone: "example.com", Record: "example.com", Type: dreamhostapi.ARecordType, Value: "192.0.2.1", Editable: dreamhostapi.Editable},
+		{Zone: "example.com", Record: "www.example.com", Type: dreamhostapi.ARecordType, Value: "192.0.2.2", Editable: dreamhostapi.Editable},
+		{Zone: "example.com", Record: "example.com", Type: dreamhostapi.NSRecordType, Value: "ns2.dreamhost.com", Editable: dreamhostapi.NotEditable},
+		{Zone: "example.com", Record: "example.com", Type: dreamhostapi.NSRecordType, Value: "ns1.dreamhost.com", Editable: dreamhostapi.NotEditable},
+		{Zone: "example.com", Record: "sub.example.com", Type: dreamhostapi.NSRecordType, Value: "ns.other.net", Editable: dreamhostapi.Editable},
+		{Zone: "example.net", Record: "example.net", Type: dreamhostapi.TXTRecordType, Value: "hello", Editable: dreamhostapi.Editable},
+	}
+
+	zones := summarizeDNSZones(records)
+	require.Len(t, zones, 3)
+
+	com := zones[0]
+	assert.Equal(t, "example.com", com.Name)
+	assert.Equal(t, 5, com.RecordCount)
+	assert.Equal(t, map[string]int{"A": 2, "NS": 3}, com.RecordCounts)
+	assert.Equal(t, 3, com.EditableCount)
+	assert.Equal(t, 2, com.NonEditableCount)
+	assert.Equal(t, []string{"ns1.dreamhost.com", "ns2.dreamhost.com"}, com.Nameservers)
+	assert.True(t, com.DreamHostNameservers())
+
+	net := zones[1]
+	assert.Equal(t, "example.net", net.Name)
+	assert.Empty(t, net.Nameservers)
+	assert.False(t, net.DreamHostNameservers())
+
+	org := zones[2]
+	assert.Equal(t, "example.org", org.Name)
+	assert.Equal(t, []string{"ns1.other.net"}, org.Nameservers)
+	assert.False(t, org.DreamHostNameservers())
+}
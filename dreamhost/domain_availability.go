@@ -0,0 +1,111 @@
+package dreamhost
+
+import (
+	"context"
+	"encoding/json"
+	"net/url"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// registrationAvailabilityCmd checks a single domain per call, the calls are
+// spaced out by the provider-wide rate limiter
+const registrationAvailabilityCmd = "domain-registration_availability"
+
+// nolint:gochecknoglobals
+var (
+	// availabilityKeys are the fields the API may report availability in
+	availabilityKeys = []string{"is_available", "available", "availability", "status"}
+
+	// domainRejectionCodes are the API errors about the candidate itself; they are
+	// reported per result, any other error fails the whole check
+	domainRejectionCodes = []string{"invalid_domain", "invalid_tld", "unsupported_tld", "reserved_domain"}
+)
+
+// domainAvailability is the availability of a candidate domain
+type domainAvailability struct {
+	Domain    string
+	Available bool
+	Price     string
+	Details   map[string]string
+	Error     string
+}
+
+// checkDomainAvailability checks the candidates in the given order, skipping
+// duplicates. Candidates the API rejects are reported with their error.
+func checkDomainAvailability(
+	ctx context.Context, api *cachedDreamhostClient, candidates []string,
+) ([]domainAvailability, error) {
+	var domains []string
+	for _, candidate := range candidates {
+		domain := normalizeDNSName(strings.TrimSpace(candidate))
+		if domain != "" && !containsString(domains, domain) {
+			domains = append(domains, domain)
+		}
+	}
+
+	results := make([]domainAvailability, 0, len(domains))
+	for _, domain := range domains {
+		var data json.RawMessage
+		err := api.CallCachedCommand(ctx, registrationAvailabilityCmd, url.Values{"domain": {domain}}, &data)
+		if err != nil {
+			if !isCommandError(err, domainRejectionCodes...) {
+				return nil, errors.Wrapf(err, "failed to check availability of %s", domain)
+			}
+			results = append(results, domainAvailability{Domain: domain, Details: map[string]string{}, Error: err.Error()})
+			continue
+		}
+
+		result, err := parseDomainAvailability(domain, data)
+		if err != nil {
+			return nil, err
+		}
+		results = append(results, result)
+	}
+
+	return results, nil
+}
+
+// parseDomainAvailability interprets the response for a domain; the API
+// returns either an object of fields or a plain status string
+func parseDomainAvailability(domain string, data json.RawMessage) (domainAvailability, error) {
+	result := domainAvailability{Domain: domain, Details: map[string]string{}}
+
+	var fields map[string]interface{}
+	if err := json.Unmarshal(data, &fields); err != nil {
+		var status string
+		if err := json.Unmarshal(data, &status); err == nil {
+			fields = map[string]interface{}{"status": status}
+		} else {
+			fields = map[string]interface{}{"status": string(data)}
+		}
+	}
+
+	for key, value := range fields {
+		switch value := value.(type) {
+		case string:
+			result.Details[key] = value
+		case nil:
+		default:
+			encoded, _ := json.Marshal(value)
+			result.Details[key] = strings.Trim(string(encoded), `"`)
+		}
+	}
+
+	found := false
+	for _, key := range availabilityKeys {
+		if value, ok := result.Details[key]; ok {
+			result.Available = isAPIFlagSet(value) || strings.EqualFold(value, "available")
+			found = true
+			break
+		}
+	}
+	if !found {
+		// a missing field must not read as unavailable
+		return result, errors.Errorf("availability of %s missing from API response: %s", domain, string(data))
+	}
+	result.Price = result.Details["price"]
+
+	return result, nil
+}
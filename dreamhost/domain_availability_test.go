@@ -0,0 +1,73 @@
+package dreamhost
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseDomainAvailability(t *testing.T) {
+	t.Parallel()
+
+	result, err := parseDomainAvailability("example.dev",
+		[]byte(`{"is_available":1,"price":"15.99","currency":"USD","premium":false,"note":null}`))
+	require.NoError(t, err)
+	assert.True(t, result.Available)
+	assert.Equal(t, "15.99", result.Price)
+	assert.Equal(t, map[string]string{"is_available": "1", "price": "15.99", "currency": "USD", "premium": "false"},
+		result.Details)
+
+	result, err = parseDomainAvailability("example.com", []byte(`"unavailable"`))
+	require.NoError(t, err)
+	assert.False(t, result.Available)
+	assert.Equal(t, map[string]string{"status": "unavailable"}, result.Details)
+
+	result, err = parseDomainAvailability("example.net", []byte(`{"availability":"available"}`))
+	require.NoError(t, err)
+	assert.True(t, result.Available)
+
+	_, err = parseDomainAvailability("example.org", []byte(`{"price":"9.99"}`))
+	assert.ErrorContains(t, err, "availability of example.org missing")
+}
+
+func TestCheckDomainAvailability(t *testing.T) {
+	t.Parallel()
+
+	caller := newFakeCommandCaller()
+	caller.Respond(registrationAvailabilityCmd+"?domain=free.dev", `{"is_available":"1","price":"12.00"}`)
+	caller.Respond(registrationAvailabilityCmd+"?domain=taken.com", `{"is_available":"0"}`)
+	caller.Fail(registrationAvailabilityCmd+"?domain=bad.invalid",
+		&commandError{Command: registrationAvailabilityCmd, Code: "invalid_tld"})
+	client := newFakeCommandClient(caller)
+
+	results, err := checkDomainAvailability(context.Background(), client,
+		[]string{"Free.dev", "taken.com", "bad.invalid", "free.dev."})
+	require.NoError(t, err)
+	require.Len(t, results, 3)
+
+	assert.Equal(t, "free.dev", results[0].Domain)
+	assert.True(t, results[0].Available)
+	assert.Equal(t, "12.00", results[0].Price)
+	assert.False(t, results[1].Available)
+	assert.Equal(t, "bad.invalid", results[2].Domain)
+	assert.Contains(t, results[2].Error, "invalid_tld")
+	assert.Len(t, caller.Calls(), 3)
+}
+
+func TestCheckDomainAvailabilityAccountErrors(t *testing.T) {
+	t.Parallel()
+
+	caller := newFakeCommandCaller()
+	caller.Respond(registrationAvailabilityCmd+"?domain=free.dev", `{"is_available":"1"}`)
+	caller.Fail(registrationAvailabilityCmd+"?domain=example.com",
+		&commandError{Command: registrationAvailabilityCmd, Code: "invalid_api_key"})
+
+	// errors that are not about the candidate fail the whole check
+	_, err := checkDomainAvailability(context.Background(), newFakeCommandClient(caller),
+		[]string{"free.dev", "example.com"})
+	require.Error(t, err)
+	assert.True(t, isCommandError(err, "invalid_api_key"))
+	assert.Contains(t, err.Error(), "failed to check availability of example.com")
+}
@@ -0,0 +1,133 @@
+package dreamhost
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+)
+
+const (
+	listRegistrationsCmd = "domain-list_registrations"
+
+	hoursPerDay = 24
+)
+
+// registrationTimeLayouts are the formats the API uses for registration dates
+// nolint:gochecknoglobals
+var registrationTimeLayouts = []string{"2006-01-02 15:04:05", "2006-01-02", time.RFC3339}
+
+// domainRegistration is a registration as returned by domain-list_registrations
+type domainRegistration struct {
+	Domain    string `json:"domain"`
+	Status    string `json:"status"`
+	Created   string `json:"created"`
+	Modified  string `json:"modified"`
+	Expires   string `json:"expires"`
+	Expired   string `json:"expired"`
+	Autorenew string `json:"autorenew"`
+	Locked    string `json:"locked"`
+	NS1       string `json:"ns1"`
+	NS2       string `json:"ns2"`
+	NS3       string `json:"ns3"`
+	NS4       string `json:"ns4"`
+}
+
+// Nameservers returns the delegated nameservers, lowercased without trailing dot
+func (r domainRegistration) Nameservers() []string {
+	nameservers := []string{}
+	for _, ns := range []string{r.NS1, r.NS2, r.NS3, r.NS4} {
+		if ns = normalizeDNSName(strings.TrimSpace(ns)); ns != "" {
+			nameservers = append(nameservers, ns)
+		}
+	}
+	return nameservers
+}
+
+// DreamHostNameservers reports whether the domain is delegated to DreamHost only
+func (r domainRegistration) DreamHostNameservers() bool {
+	return dnsZone{Nameservers: r.Nameservers()}.DreamHostNameservers()
+}
+
+// RegistrarStatus returns the status reported by the API, falling back to the expired flag
+func (r domainRegistration) RegistrarStatus() string {
+	switch {
+	case r.Status != "":
+		return r.Status
+	case isAPIFlagSet(r.Expired):
+		return "expired"
+	default:
+		return "active"
+	}
+}
+
+// ExpiresAt parses the expiry date
+func (r domainRegistration) ExpiresAt() (time.Time, error) {
+	for _, layout := range registrationTimeLayouts {
+		if expires, err := time.Parse(layout, strings.TrimSpace(r.Expires)); err == nil {
+			return expires.UTC(), nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("unknown expiry date format for %s: %q", r.Domain, r.Expires)
+}
+
+// listRegistrations returns the registrations of the account, sorted by domain
+func listRegistrations(ctx context.Context, api *cachedDreamhostClient) ([]domainRegistration, error) {
+	var registrations []domainRegistration
+	if err := api.CallCachedCommand(ctx, listRegistrationsCmd, nil, &registrations); err != nil {
+		return nil, err
+	}
+
+	sort.SliceStable(registrations, func(i, j int) bool {
+		return registrations[i].Domain < registrations[j].Domain
+	})
+	return registrations, nil
+}
+
+// daysUntil returns the number of whole days from now until t, negative once t has passed
+func daysUntil(t, now time.Time) int {
+	return int(math.Floor(t.Sub(now).Hours() / hoursPerDay))
+}
+
+// registrationWarnings warns about registrations expiring within the given
+// number of days, or already expired
+func registrationWarnings(registrations []domainRegistration, withinDays int, now time.Time) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	for _, registration := range registrations {
+		expires, err := registration.ExpiresAt()
+		if err != nil {
+			diags = append(diags, diag.Diagnostic{
+				Severity: diag.Warning,
+				Summary:  "Unknown expiry date of " + registration.Domain,
+				Detail:   err.Error(),
+			})
+			continue
+		}
+
+		days := daysUntil(expires, now)
+		if days > withinDays {
+			continue
+		}
+
+		renewal := "Autorenew is disabled, renew it manually."
+		if isAPIFlagSet(registration.Autorenew) {
+			renewal = "Autorenew is enabled, make sure the payment method on file is valid."
+		}
+		summary := fmt.Sprintf("Domain %s expires in %d days", registration.Domain, days)
+		if days < 0 {
+			summary = fmt.Sprintf("Domain %s expired %d days ago", registration.Domain, -days)
+		}
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Warning,
+			Summary:  summary,
+			Detail:   fmt.Sprintf("The registration of %s expires on %s. %s", registration.Domain, expires.Format("2006-01-02"), renewal),
+		})
+	}
+
+	return diags
+}
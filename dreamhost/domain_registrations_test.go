@@ -0,0 +1,113 @@
+package dreamhost
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDomainRegistration(t *testing.T) {
+	t.Parallel()
+
+	registration := domainRegistration{
+		Domain: "example.com", Expires: "2026-03-01 12:00:00", Expired: "no",
+		NS1: "NS1.DreamHost.com.", NS2: "ns2.dreamhost.com", NS3: "",
+	}
+	assert.Equal(t, []string{"ns1.dreamhost.com", "ns2.dreamhost.com"}, registration.Nameservers())
+	assert.True(t, registration.DreamHostNameservers())
+	assert.Equal(t, "active", registration.RegistrarStatus())
+
+	expires, err := registration.ExpiresAt()
+	require.NoError(t, err)
+	assert.Equal(t, time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC), expires)
+
+	registration.NS3 = "ns1.other-dns.net"
+	registration.Expired = "yes"
+	assert.False(t, registration.DreamHostNameservers())
+	assert.Equal(t, "expired", registration.RegistrarStatus())
+	assert.False(t, domainRegistration{}.DreamHostNameservers())
+
+	_, err = domainRegistration{Domain: "example.com", Expires: "next year"}.ExpiresAt()
+	assert.Error(t, err)
+}
+
+func TestRegistrationWarnings(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	registrations := []domainRegistration{
+		{Domain: "soon.com", Expires: "2026-01-11", Autorenew: "no"},
+		{Domain: "later.com", Expires: "2026-06-01", Autorenew: "yes"},
+		{Domain: "gone.com", Expires: "2025-12-30", Autorenew: "yes"},
+		{Domain: "broken.com", Expires: ""},
+	}
+
+	diags := registrationWarnings(registrations, 30, now)
+	require.Len(t, diags, 3)
+	for _, d := range diags {
+		assert.Equal(t, diag.Warning, d.Severity)
+	}
+	assert.Equal(t, "Domain soon.com expires in 10 days", diags[0].Summary)
+	assert.Contains(t, diags[0].Detail, "renew it manually")
+	assert.Equal(t, "Domain gone.com expired 2 days ago", diags[1].Summary)
+	assert.Equal(t, "Unknown expiry date of broken.com", diags[2].Summary)
+}
+
+func TestDataSourceDomainRegistrationsRead(t *testing.T) {
+	t.Parallel()
+
+	expires := time.Now().UTC().AddDate(0, 0, 5).Format("2006-01-02")
+	caller := newFakeCommandCaller()
+	caller.Respond(listRegistrationsCmd, `[
+		{"domain":"example.org","expires":"2099-01-01","autorenew":"yes","locked":"yes",
+		 "ns1":"ns1.dreamhost.com","ns2":"ns2.dreamhost.com","ns3":"ns3.dreamhost.com"},
+		{"domain":"example.com","expires":"`+expires+`","autorenew":"no","locked":"no",
+		 "ns1":"ns1.example.net","ns2":"ns2.example.net"}
+	]`)
+
+	d := schema.TestResourceDataRaw(t, dataSourceDomainRegistrations().Schema, map[string]interface{}{
+		"expiring_within_days": 30,
+	})
+	diags := dataSourceDomainRegistrationsRead(context.Background(), d, newFakeCommandClient(caller))
+	require.False(t, diags.HasError(), "%v", diags)
+	require.Len(t, diags, 1)
+	assert.Contains(t, diags[0].Summary, "example.com expires in")
+
+	assert.Equal(t, []interface{}{"example.com"}, d.Get("expiring"))
+	assert.Equal(t, []interface{}{"example.com"}, d.Get("nameservers_not_dreamhost"))
+	assert.Equal(t, "example.com", d.Get("registrations.0.domain"))
+	assert.Equal(t, false, d.Get("registrations.0.autorenew"))
+	assert.Equal(t, true, d.Get("registrations.1.dreamhost_nameservers"))
+	assert.Equal(t, "2099-01-01", d.Get("registrations.1.expires"))
+}
+
+func TestDataSourceDomainRegistrationsExpiringToday(t *testing.T) {
+	t.Parallel()
+
+	caller := newFakeCommandCaller()
+	caller.Respond(listRegistrationsCmd, `[
+		{"domain":"example.org","expires":"2099-01-01","autorenew":"yes"},
+		{"domain":"gone.com","expires":"2001-01-01","autorenew":"no"}
+	]`)
+	client := newFakeCommandClient(caller)
+
+	d := schema.TestResourceDataRaw(t, dataSourceDomainRegistrations().Schema, map[string]interface{}{
+		"expiring_within_days": 0,
+	})
+	diags := dataSourceDomainRegistrationsRead(context.Background(), d, client)
+	require.False(t, diags.HasError(), "%v", diags)
+	assert.Len(t, diags, 1)
+	assert.Equal(t, []interface{}{"gone.com"}, d.Get("expiring"))
+
+	// without the attribute nothing is checked
+	unset := schema.TestResourceDataRaw(t, dataSourceDomainRegistrations().Schema, map[string]interface{}{})
+	diags = dataSourceDomainRegistrationsRead(context.Background(), unset, client)
+	require.False(t, diags.HasError(), "%v", diags)
+	assert.Empty(t, diags)
+	assert.Empty(t, unset.Get("expiring"))
+}
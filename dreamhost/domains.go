@@ -0,0 +1,98 @@
+package dreamhost
+
+import (
+	"context"
+	"sort"
+	"strings"
+)
+
+const (
+	listDomainsCmd = "domain-list_domains"
+
+	wwwAdd = "add_www"
+)
+
+// hostedDomain is a domain as returned by domain-list_domains; the API encodes
+// every field, including flags, as a string
+type hostedDomain struct {
+	AccountID   string `json:"account_id"`
+	Domain      string `json:"domain"`
+	Home        string `json:"home"`
+	Type        string `json:"type"`
+	HostingType string `json:"hosting_type"`
+	UniqueIP    string `json:"unique_ip"`
+	User        string `json:"user"`
+	Path        string `json:"path"`
+	OutsideURL  string `json:"outside_url"`
+	WWWOrNot    string `json:"www_or_not"`
+	PHP         string `json:"php"`
+	PHPFcgid    string `json:"php_fcgid"`
+	FastCGI     string `json:"fastcgi"`
+	Passenger   string `json:"passenger"`
+	HTTPS       string `json:"https"`
+}
+
+// listDomains returns the hosted domains of the account, sorted by name
+func listDomains(ctx context.Context, api *cachedDreamhostClient) ([]hostedDomain, error) {
+	var domains []hostedDomain
+	if err := api.CallCachedCommand(ctx, listDomainsCmd, nil, &domains); err != nil {
+		return nil, err
+	}
+
+	sort.SliceStable(domains, func(i, j int) bool {
+		return domains[i].Domain < domains[j].Domain
+	})
+	return domains, nil
+}
+
+// matchesServer compares a home server with a filter given either as full
+// hostname (spork.dreamhost.com) or as its first label (spork)
+func matchesServer(home, server string) bool {
+	home, server = normalizeDNSName(home), normalizeDNSName(server)
+	return home == server || strings.SplitN(home, ".", 2)[0] == server
+}
+
+// filterDomains returns the domains matching all non-empty criteria
+func filterDomains(domains []hostedDomain, domainType, hostingType, server string) []hostedDomain {
+	filtered := []hostedDomain{}
+	for _, domain := range domains {
+		if domainType != "" && domain.Type != domainType {
+			continue
+		}
+		if hostingType != "" && domain.HostingType != hostingType {
+			continue
+		}
+		if server != "" && !matchesServer(domain.Home, server) {
+			continue
+		}
+		filtered = append(filtered, domain)
+	}
+	return filtered
+}
+
+// flattenDomain converts a domain into the attributes shared by the domain data sources
+func flattenDomain(domain hostedDomain) map[string]interface{} {
+	return map[string]interface{}{
+		"domain":        domain.Domain,
+		"account_id":    domain.AccountID,
+		"type":          domain.Type,
+		"hosting_type":  domain.HostingType,
+		"home":          domain.Home,
+		"unique_ip":     domain.UniqueIP,
+		"user":          domain.User,
+		"path":          domain.Path,
+		"outside_url":   domain.OutsideURL,
+		"www_mode":      domain.WWWOrNot,
+		"www_prefixed":  domain.WWWOrNot == wwwAdd,
+		"php_version":   domain.PHP,
+		"php_fcgid":     isAPIFlagSet(domain.PHPFcgid),
+		"fastcgi":       isAPIFlagSet(domain.FastCGI),
+		"passenger":     isAPIFlagSet(domain.Passenger),
+		"https_enabled": isAPIFlagSet(domain.HTTPS),
+	}
+}
+
+// isAPIFlagSet interprets the "0"/"1" flags of the API
+func isAPIFlagSet(flag string) bool {
+	return flag == "1" || strings.EqualFold(flag, "yes") || strings.EqualFold(flag, "true")
+}
@@ -0,0 +1,66 @@
+package dreamhost
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testDomainsResponse = `[
+	{"account_id":"1","domain":"www.example.org","home":"spork.dreamhost.com","type":"http","hosting_type":"full",
+	 "user":"web2","path":"example.org","www_or_not":"both_work","php":"php8.2","php_fcgid":"1","https":"0"},
+	{"account_id":"1","domain":"example.com","home":"quark.dreamhost.com","type":"http","hosting_type":"full",
+	 "user":"web1","path":"example.com","www_or_not":"add_www","php":"php8.3","php_fcgid":"1","https":"1"},
+	{"account_id":"1","domain":"example.net","home":"","type":"redirect","hosting_type":"",
+	 "outside_url":"https://example.com/","www_or_not":"","php":"","php_fcgid":"0"}
+]`
+
+func TestListDomains(t *testing.T) {
+	t.Parallel()
+
+	caller := newFakeCommandCaller()
+	caller.Respond(listDomainsCmd, testDomainsResponse)
+
+	domains, err := listDomains(context.Background(), newFakeCommandClient(caller))
+	require.NoError(t, err)
+	require.Len(t, domains, 3)
+	assert.Equal(t, "example.com", domains[0].Domain)
+	assert.Equal(t, "example.net", domains[1].Domain)
+	assert.Equal(t, "www.example.org", domains[2].Domain)
+
+	flat := flattenDomain(domains[0])
+	assert.Equal(t, "quark.dreamhost.com", flat["home"])
+	assert.Equal(t, "php8.3", flat["php_version"])
+	assert.Equal(t, true, flat["www_prefixed"])
+	assert.Equal(t, true, flat["https_enabled"])
+	assert.Equal(t, false, flat["passenger"])
+
+	flat = flattenDomain(domains[2])
+	assert.Equal(t, false, flat["www_prefixed"])
+	assert.Equal(t, false, flat["https_enabled"])
+}
+
+func TestFilterDomains(t *testing.T) {
+	t.Parallel()
+
+	caller := newFakeCommandCaller()
+	caller.Respond(listDomainsCmd, testDomainsResponse)
+	domains, err := listDomains(context.Background(), newFakeCommandClient(caller))
+	require.NoError(t, err)
+
+	names := func(domains []hostedDomain) []string {
+		result := []string{}
+		for _, domain := range domains {
+			result = append(result, domain.Domain)
+		}
+		return result
+	}
+
+	assert.Equal(t, []string{"example.com", "www.example.org"}, names(filterDomains(domains, "http", "", "")))
+	assert.Equal(t, []string{"example.com", "www.example.org"}, names(filterDomains(domains, "", "full", "")))
+	assert.Equal(t, []string{"www.example.org"}, names(filterDomains(domains, "", "", "spork")))
+	assert.Equal(t, []string{"example.com"}, names(filterDomains(domains, "http", "", "quark.dreamhost.com.")))
+	assert.Empty(t, filterDomains(domains, "redirect", "full", ""))
+}
@@ -0,0 +1,131 @@
+package dreamhost
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	addFilterCmd    = "mail-add_filter"
+	listFiltersCmd  = "mail-list_filters"
+	removeFilterCmd = "mail-remove_filter"
+
+	mailFilterIDParts = 7
+
+	apiYes = "yes"
+	apiNo  = "no"
+)
+
+// mailFilterFields and mailFilterActions are the values mail-add_filter accepts
+// nolint:gochecknoglobals
+var (
+	mailFilterFields  = []string{"subject", "from", "to", "cc", "body", "reply-to", "headers"}
+	mailFilterActions = []string{"move", "forward", "delete", "add_subject", "forward_shell", "and", "or"}
+)
+
+// mailFilter is a filter as listed by mail-list_filters
+type mailFilter struct {
+	AccountID   string `json:"account_id"`
+	Address     string `json:"address"`
+	Rank        string `json:"rank"`
+	FilterOn    string `json:"filter_on"`
+	Filter      string `json:"filter"`
+	Action      string `json:"action"`
+	ActionValue string `json:"action_value"`
+	Contains    string `json:"contains"`
+	Stop        string `json:"stop"`
+}
+
+// params returns the parameters identifying the filter to mail-add_filter and mail-remove_filter
+func (f mailFilter) params() url.Values {
+	return url.Values{
+		"address":      {f.Address},
+		"filter_on":    {f.FilterOn},
+		"filter":       {f.Filter},
+		"action":       {f.Action},
+		"action_value": {f.ActionValue},
+		"contains":     {f.Contains},
+		"stop":         {f.Stop},
+	}
+}
+
+// matches compares the identifying fields, ignoring the case of the address
+func (f mailFilter) matches(other mailFilter) bool {
+	return strings.EqualFold(f.Address, other.Address) &&
+		f.FilterOn == other.FilterOn &&
+		f.Filter == other.Filter &&
+		f.Action == other.Action &&
+		f.ActionValue == other.ActionValue &&
+		isAPIFlagSet(f.Contains) == isAPIFlagSet(other.Contains) &&
+		isAPIFlagSet(f.Stop) == isAPIFlagSet(other.Stop)
+}
+
+// mailFilterToID renders the resource ID; the filter text comes last so it may contain the separator
+func mailFilterToID(f mailFilter) string {
+	return strings.Join([]string{
+		f.Address, f.FilterOn, f.Action, f.ActionValue, apiFlag(isAPIFlagSet(f.Contains)), apiFlag(isAPIFlagSet(f.Stop)), f.Filter,
+	}, "|")
+}
+
+func idToMailFilter(id string) (*mailFilter, error) {
+	parts := strings.SplitN(id, "|", mailFilterIDParts)
+	if len(parts) != mailFilterIDParts {
+		return nil, errors.New("could not determine mail filter from ID, expected " +
+			"address|filter_on|action|action_value|contains|stop|filter")
+	}
+	return &mailFilter{
+		Address:     parts[0],
+		FilterOn:    parts[1],
+		Action:      parts[2],
+		ActionValue: parts[3],
+		Contains:    parts[4],
+		Stop:        parts[5],
+		Filter:      parts[6],
+	}, nil
+}
+
+// apiFlag renders a boolean the way the mail commands expect it
+func apiFlag(value bool) string {
+	if value {
+		return apiYes
+	}
+	return apiNo
+}
+
+// mailFilterNeedsValue reports whether the action requires an action_value
+func mailFilterNeedsValue(action string) bool {
+	switch action {
+	case "move", "forward", "add_subject", "forward_shell":
+		return true
+	default:
+		return false
+	}
+}
+
+func validateMailFilter(f mailFilter) error {
+	if mailFilterNeedsValue(f.Action) && f.ActionValue == "" {
+		return fmt.Errorf("action %q requires action_value", f.Action)
+	}
+	if !mailFilterNeedsValue(f.Action) && f.ActionValue != "" {
+		return fmt.Errorf("action %q does not take an action_value", f.Action)
+	}
+	return nil
+}
+
+// findMailFilter looks the filter up in the cached listing
+func findMailFilter(ctx context.Context, api *cachedDreamhostClient, filter mailFilter) (*mailFilter, error) {
+	var filters []mailFilter
+	if err := api.CallCachedCommand(ctx, listFiltersCmd, nil, &filters); err != nil {
+		return nil, err
+	}
+	for i := range filters {
+		if filters[i].matches(filter) {
+			return &filters[i], nil
+		}
+	}
+	return nil, nil
+}
@@ -0,0 +1,78 @@
+package dreamhost
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testMailFiltersResponse = `[
+	{"account_id":"1","address":"Help@example.com","rank":"2","filter_on":"subject","filter":"[ticket|urgent]",
+	 "action":"move","action_value":"Tickets","contains":"yes","stop":"no"},
+	{"account_id":"1","address":"help@example.com","rank":"1","filter_on":"from","filter":"spam@example.net",
+	 "action":"delete","action_value":"","contains":"yes","stop":"yes"}
+]`
+
+func TestMailFilterID(t *testing.T) {
+	t.Parallel()
+
+	filter := mailFilter{
+		Address: "help@example.com", FilterOn: "subject", Filter: "[ticket|urgent]",
+		Action: "move", ActionValue: "Tickets", Contains: "1", Stop: "0",
+	}
+	id := mailFilterToID(filter)
+	assert.Equal(t, "help@example.com|subject|move|Tickets|yes|no|[ticket|urgent]", id)
+
+	parsed, err := idToMailFilter(id)
+	require.NoError(t, err)
+	assert.True(t, parsed.matches(filter))
+	assert.Equal(t, "[ticket|urgent]", parsed.Filter)
+
+	_, err = idToMailFilter("help@example.com|subject")
+	assert.Error(t, err)
+}
+
+func TestValidateMailFilter(t *testing.T) {
+	t.Parallel()
+
+	assert.NoError(t, validateMailFilter(mailFilter{Action: "move", ActionValue: "Tickets"}))
+	assert.NoError(t, validateMailFilter(mailFilter{Action: "delete"}))
+	assert.EqualError(t, validateMailFilter(mailFilter{Action: "forward"}), `action "forward" requires action_value`)
+	assert.Error(t, validateMailFilter(mailFilter{Action: "delete", ActionValue: "Trash"}))
+}
+
+func TestResourceMailFilterReadAndDelete(t *testing.T) {
+	t.Parallel()
+
+	caller := newFakeCommandCaller()
+	caller.Respond(listFiltersCmd, testMailFiltersResponse)
+	caller.Respond(removeFilterCmd, `"success"`)
+	client := newFakeCommandClient(caller)
+
+	d := schema.TestResourceDataRaw(t, resourceMailFilter().Schema, map[string]interface{}{})
+	d.SetId("help@example.com|subject|move|Tickets|yes|no|[ticket|urgent]")
+
+	diags := resourceMailFilterRead(context.Background(), d, client)
+	require.False(t, diags.HasError(), "%v", diags)
+	assert.Equal(t, "Help@example.com", d.Get("address"))
+	assert.Equal(t, "[ticket|urgent]", d.Get("filter"))
+	assert.Equal(t, true, d.Get("contains"))
+	assert.Equal(t, false, d.Get("stop"))
+	assert.Equal(t, 2, d.Get("rank"))
+
+	diags = resourceMailFilterDelete(context.Background(), d, client)
+	require.False(t, diags.HasError(), "%v", diags)
+	calls := caller.Calls()
+	require.Len(t, calls, 2)
+	assert.Contains(t, calls[1], removeFilterCmd+"?action=move&action_value=Tickets&address=Help%40example.com")
+
+	// filters removed outside of Terraform are dropped from state
+	missing := schema.TestResourceDataRaw(t, resourceMailFilter().Schema, map[string]interface{}{})
+	missing.SetId("help@example.com|body|delete||yes|yes|unsubscribe")
+	diags = resourceMailFilterRead(context.Background(), missing, client)
+	require.False(t, diags.HasError(), "%v", diags)
+	assert.Empty(t, missing.Id())
+}
@@ -0,0 +1,289 @@
+package dreamhost
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/pkg/errors"
+)
+
+const (
+	addMySQLHostnameCmd    = "mysql-add_hostname"
+	listMySQLHostnamesCmd  = "mysql-list_hostnames"
+	removeMySQLHostnameCmd = "mysql-remove_hostname"
+	listMySQLDatabasesCmd  = "mysql-list_dbs"
+	listMySQLUsersCmd      = "mysql-list_users"
+	addMySQLUserCmd        = "mysql-add_user"
+	removeMySQLUserCmd     = "mysql-remove_user"
+
+	// mysqlHostnameNotFoundCode is returned when removing a hostname that does not exist
+	mysqlHostnameNotFoundCode = "no_such_hostname"
+	// mysqlUserNotFoundCode is returned when removing a user that does not exist
+	mysqlUserNotFoundCode = "no_such_user"
+
+	mysqlUserIDParts = 2
+	mysqlAnyHost     = "%"
+)
+
+// mysqlPrivileges are the privilege flags of mysql-list_users, in the order the API documents them
+// nolint:gochecknoglobals
+var mysqlPrivileges = []string{"select", "insert", "update", "delete", "create", "drop", "index", "alter"}
+
+// mysqlHostname is a hostname as returned by mysql-list_hostnames
+type mysqlHostname struct {
+	AccountID string `json:"account_id"`
+	Domain    string `json:"domain"`
+	Home      string `json:"home"`
+}
+
+// mysqlDatabase is a database as returned by mysql-list_dbs
+type mysqlDatabase struct {
+	AccountID   string `json:"account_id"`
+	Database    string `json:"db"`
+	Description string `json:"description"`
+	Home        string `json:"home"`
+	DiskUsageMB string `json:"disk_usage_mb"`
+}
+
+// mysqlUser is a user grant as returned by mysql-list_users, one per user, database and host
+type mysqlUser struct {
+	AccountID  string `json:"account_id"`
+	Username   string `json:"username"`
+	Database   string `json:"db"`
+	Home       string `json:"home"`
+	Host       string `json:"host"`
+	SelectPriv string `json:"select_priv"`
+	InsertPriv string `json:"insert_priv"`
+	UpdatePriv string `json:"update_priv"`
+	DeletePriv string `json:"delete_priv"`
+	CreatePriv string `json:"create_priv"`
+	DropPriv   string `json:"drop_priv"`
+	IndexPriv  string `json:"index_priv"`
+	AlterPriv  string `json:"alter_priv"`
+}
+
+// privileges returns the granted privileges in the order of mysqlPrivileges
+func (u mysqlUser) privileges() []string {
+	flags := []string{
+		u.SelectPriv, u.InsertPriv, u.UpdatePriv, u.DeletePriv, u.CreatePriv, u.DropPriv, u.IndexPriv, u.AlterPriv,
+	}
+	privileges := []string{}
+	for i, flag := range flags {
+		if isAPIFlagSet(flag) || flag == "Y" {
+			privileges = append(privileges, mysqlPrivileges[i])
+		}
+	}
+	return privileges
+}
+
+// mysqlUserParams returns the mysql-add_user parameters granting the
+// privileges on the database from each of the hosts
+func mysqlUserParams(database, username, password string, hosts, privileges []string) url.Values {
+	params := url.Values{
+		"db":        {database},
+		"user":      {username},
+		"password":  {password},
+		"hostnames": {strings.Join(hosts, "\n")},
+	}
+	for _, privilege := range mysqlPrivileges {
+		flag := "N"
+		if containsString(privileges, privilege) {
+			flag = "Y"
+		}
+		params.Set(privilege, flag)
+	}
+	return params
+}
+
+func mysqlUserToID(database, username string) string {
+	return database + "|" + username
+}
+
+func idToMySQLUser(id string) (database, username string, err error) {
+	parts := strings.Split(id, "|")
+	if len(parts) != mysqlUserIDParts || parts[0] == "" || parts[1] == "" {
+		return "", "", errors.New("could not determine MySQL user from ID, expected database|username")
+	}
+	return parts[0], parts[1], nil
+}
+
+// listMySQLDatabases returns the databases sorted by name, optionally
+// restricted to a database server and a database name
+func listMySQLDatabases(ctx context.Context, api *cachedDreamhostClient, home, database string) ([]mysqlDatabase, error) {
+	var databases []mysqlDatabase
+	if err := api.CallCachedCommand(ctx, listMySQLDatabasesCmd, nil, &databases); err != nil {
+		return nil, errors.Wrap(err, "failed to list MySQL databases")
+	}
+
+	filtered := []mysqlDatabase{}
+	for _, db := range databases {
+		if home != "" && !matchesServer(db.Home, home) {
+			continue
+		}
+		if database != "" && db.Database != database {
+			continue
+		}
+		filtered = append(filtered, db)
+	}
+	sort.SliceStable(filtered, func(i, j int) bool {
+		return filtered[i].Database < filtered[j].Database
+	})
+
+	return filtered, nil
+}
+
+// listMySQLUsers returns the user grants sorted by username, database and
+// host, optionally restricted to a database server, database and username
+func listMySQLUsers(ctx context.Context, api *cachedDreamhostClient, home, database, username string) ([]mysqlUser, error) {
+	var users []mysqlUser
+	if err := api.CallCachedCommand(ctx, listMySQLUsersCmd, nil, &users); err != nil {
+		return nil, errors.Wrap(err, "failed to list MySQL users")
+	}
+
+	filtered := []mysqlUser{}
+	for _, user := range users {
+		if home != "" && !matchesServer(user.Home, home) {
+			continue
+		}
+		if database != "" && user.Database != database {
+			continue
+		}
+		if username != "" && user.Username != username {
+			continue
+		}
+		filtered = append(filtered, user)
+	}
+	sort.SliceStable(filtered, func(i, j int) bool {
+		left, right := filtered[i], filtered[j]
+		if left.Username != right.Username {
+			return left.Username < right.Username
+		}
+		if left.Database != right.Database {
+			return left.Database < right.Database
+		}
+		return left.Host < right.Host
+	})
+
+	return filtered, nil
+}
+
+// parseDiskUsage parses the disk usage the API reports in megabytes
+func parseDiskUsage(value string) float64 {
+	usage, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		return 0
+	}
+	return usage
+}
+
+// findMySQLHostname looks the hostname up in the cached listing
+func findMySQLHostname(ctx context.Context, api *cachedDreamhostClient, hostname string) (*mysqlHostname, error) {
+	var hostnames []mysqlHostname
+	if err := api.CallCachedCommand(ctx, listMySQLHostnamesCmd, nil, &hostnames); err != nil {
+		return nil, err
+	}
+	hostname = normalizeDNSName(hostname)
+	for i := range hostnames {
+		if normalizeDNSName(hostnames[i].Domain) == hostname {
+			return &hostnames[i], nil
+		}
+	}
+	return nil, nil
+}
+
+// mysqlHostnameParams identifies the hostname to mysql-add_hostname and mysql-remove_hostname
+func mysqlHostnameParams(hostname, home string) url.Values {
+	params := url.Values{"hostname": {hostname}}
+	if home != "" {
+		params.Set("home", home)
+	}
+	return params
+}
+
+// waitForMySQLHostname waits for a MySQL hostname to appear in the API
+func waitForMySQLHostname(ctx context.Context, client *cachedDreamhostClient, hostname string) (*mysqlHostname, error) {
+	stateConf := &resource.StateChangeConf{
+		Pending:    []string{"pending"},
+		Target:     []string{"available"},
+		Refresh:    mysqlHostnameStateRefreshFunc(ctx, client, hostname),
+		Timeout:    retryTimeout,
+		Delay:      retryDelay,
+		MinTimeout: retryMinDelay,
+	}
+
+	result, err := stateConf.WaitForStateContext(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "error waiting for MySQL hostname")
+	}
+
+	found, ok := result.(*mysqlHostname)
+	if !ok || found == nil {
+		return nil, fmt.Errorf("unexpected type from state refresh: %T", result)
+	}
+
+	return found, nil
+}
+
+// waitForMySQLHostnameDeletion waits for a MySQL hostname to disappear from the API
+func waitForMySQLHostnameDeletion(ctx context.Context, client *cachedDreamhostClient, hostname string) error {
+	stateConf := &resource.StateChangeConf{
+		Pending:    []string{"deleting"},
+		Target:     []string{"deleted"},
+		Refresh:    mysqlHostnameDeletionStateRefreshFunc(ctx, client, hostname),
+		Timeout:    retryTimeout,
+		Delay:      retryDelay,
+		MinTimeout: retryMinDelay,
+	}
+
+	if _, err := stateConf.WaitForStateContext(ctx); err != nil {
+		return errors.Wrap(err, "error waiting for MySQL hostname deletion")
+	}
+
+	return nil
+}
+
+// mysqlHostnameStateRefreshFunc returns a function that checks if a MySQL hostname exists
+func mysqlHostnameStateRefreshFunc(ctx context.Context, client *cachedDreamhostClient, hostname string) resource.StateRefreshFunc {
+	return func() (interface{}, string, error) {
+		// Invalidate cache to get fresh data
+		client.cache.InvalidateCommands()
+
+		found, err := findMySQLHostname(ctx, client, hostname)
+		if err != nil {
+			return nil, "", err
+		}
+
+		if found == nil {
+			return nil, "pending", nil
+		}
+
+		return found, "available", nil
+	}
+}
+
+// mysqlHostnameDeletionStateRefreshFunc returns a function that checks if a MySQL hostname has been deleted
+func mysqlHostnameDeletionStateRefreshFunc(
+	ctx context.Context, client *cachedDreamhostClient, hostname string,
+) resource.StateRefreshFunc {
+	return func() (interface{}, string, error) {
+		// Invalidate cache to get fresh data
+		client.cache.InvalidateCommands()
+
+		found, err := findMySQLHostname(ctx, client, hostname)
+		if err != nil {
+			return nil, "", err
+		}
+
+		if found != nil {
+			return found, "deleting", nil
+		}
+
+		// a nil result would count as not found rather than reaching the target
+		return hostname, "deleted", nil
+	}
+}
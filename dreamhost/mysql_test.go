@@ -0,0 +1,298 @@
+package dreamhost
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testMySQLHostnamesResponse = `[
+	{"account_id":"1","domain":"mysql.example.com","home":"mysql-a.dreamhost.com"},
+	{"account_id":"1","domain":"db.example.org","home":"mysql-b.dreamhost.com"}
+]`
+
+func TestMySQLHostnameStateRefreshFunc(t *testing.T) {
+	t.Parallel()
+
+	caller := newFakeCommandCaller()
+	caller.Respond(listMySQLHostnamesCmd, `[]`)
+	client := newFakeCommandClient(caller)
+
+	refresh := mysqlHostnameStateRefreshFunc(context.Background(), client, "MySQL.example.com.")
+	deletion := mysqlHostnameDeletionStateRefreshFunc(context.Background(), client, "mysql.example.com")
+
+	_, state, err := refresh()
+	require.NoError(t, err)
+	assert.Equal(t, "pending", state)
+	result, state, err := deletion()
+	require.NoError(t, err)
+	assert.Equal(t, "deleted", state)
+	assert.NotNil(t, result)
+
+	// every refresh bypasses the cache
+	caller.Respond(listMySQLHostnamesCmd, testMySQLHostnamesResponse)
+	result, state, err = refresh()
+	require.NoError(t, err)
+	assert.Equal(t, "available", state)
+	assert.Equal(t, "mysql-a.dreamhost.com", result.(*mysqlHostname).Home)
+	_, state, err = deletion()
+	require.NoError(t, err)
+	assert.Equal(t, "deleting", state)
+}
+
+func TestWaitForMySQLHostnameDeletion(t *testing.T) {
+	t.Parallel()
+
+	caller := newFakeCommandCaller()
+	caller.Respond(listMySQLHostnamesCmd, testMySQLHostnamesResponse)
+	client := newFakeCommandClient(caller)
+
+	// the hostname is already gone, the wait has to reach its target before the deadline
+	ctx, cancel := context.WithTimeout(context.Background(), 3*retryDelay)
+	defer cancel()
+	require.NoError(t, waitForMySQLHostnameDeletion(ctx, client, "gone.example.com"))
+}
+
+func TestResourceMySQLHostnameRead(t *testing.T) {
+	t.Parallel()
+
+	caller := newFakeCommandCaller()
+	caller.Respond(listMySQLHostnamesCmd, testMySQLHostnamesResponse)
+	client := newFakeCommandClient(caller)
+
+	d := schema.TestResourceDataRaw(t, resourceMySQLHostname().Schema, map[string]interface{}{})
+	d.SetId("db.example.org")
+	diags := resourceMySQLHostnameRead(context.Background(), d, client)
+	require.False(t, diags.HasError(), "%v", diags)
+	assert.Equal(t, "db.example.org", d.Get("hostname"))
+	assert.Equal(t, "mysql-b.dreamhost.com", d.Get("home"))
+
+	// the short server name is kept, a moved hostname shows up as a change
+	short := schema.TestResourceDataRaw(t, resourceMySQLHostname().Schema, map[string]interface{}{
+		"hostname": "db.example.org", "home": "mysql-b",
+	})
+	short.SetId("db.example.org")
+	diags = resourceMySQLHostnameRead(context.Background(), short, client)
+	require.False(t, diags.HasError(), "%v", diags)
+	assert.Equal(t, "mysql-b", short.Get("home"))
+	moved := schema.TestResourceDataRaw(t, resourceMySQLHostname().Schema, map[string]interface{}{
+		"hostname": "db.example.org", "home": "mysql-a",
+	})
+	moved.SetId("db.example.org")
+	diags = resourceMySQLHostnameRead(context.Background(), moved, client)
+	require.False(t, diags.HasError(), "%v", diags)
+	assert.Equal(t, "mysql-b.dreamhost.com", moved.Get("home"))
+
+	// existing hostnames must be imported rather than added again
+	existing := schema.TestResourceDataRaw(t, resourceMySQLHostname().Schema, map[string]interface{}{
+		"hostname": "mysql.example.com", "home": "mysql-a.dreamhost.com",
+	})
+	diags = resourceMySQLHostnameCreate(context.Background(), existing, client)
+	require.True(t, diags.HasError())
+	assert.Contains(t, diags[0].Summary, `import it with ID "mysql.example.com"`)
+
+	// hostnames removed outside of Terraform are dropped from state
+	missing := schema.TestResourceDataRaw(t, resourceMySQLHostname().Schema, map[string]interface{}{})
+	missing.SetId("mysql.example.net")
+	diags = resourceMySQLHostnameRead(context.Background(), missing, client)
+	require.False(t, diags.HasError(), "%v", diags)
+	assert.Empty(t, missing.Id())
+}
+
+func TestResourceMySQLHostnameCreateKeepsAddedHostname(t *testing.T) {
+	t.Parallel()
+
+	caller := newFakeCommandCaller()
+	caller.Respond(listMySQLHostnamesCmd, testMySQLHostnamesResponse)
+	caller.Respond(addMySQLHostnameCmd, `"success"`)
+	client := newFakeCommandClient(caller)
+
+	// the hostname never shows up, but it was added and must be tracked
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	d := schema.TestResourceDataRaw(t, resourceMySQLHostname().Schema, map[string]interface{}{
+		"hostname": "new.example.com", "home": "mysql-a.dreamhost.com",
+	})
+	diags := resourceMySQLHostnameCreate(ctx, d, client)
+	require.True(t, diags.HasError())
+	assert.Equal(t, "new.example.com", d.Id())
+}
+
+const (
+	testMySQLDatabasesResponse = `[
+	{"account_id":"1","db":"shop","description":"Shop","home":"mysql-a.dreamhost.com","disk_usage_mb":"12.5"},
+	{"account_id":"1","db":"blog","description":"","home":"mysql-b.dreamhost.com","disk_usage_mb":"3"},
+	{"account_id":"1","db":"analytics","description":"Stats","home":"mysql-a.dreamhost.com","disk_usage_mb":""}
+]`
+	testMySQLUsersResponse = `[
+	{"account_id":"1","username":"shop_app","db":"shop","home":"mysql-a.dreamhost.com","host":"%.example.com",
+	 "select_priv":"Y","insert_priv":"Y","update_priv":"Y","delete_priv":"Y","create_priv":"N","drop_priv":"N",
+	 "index_priv":"N","alter_priv":"N"},
+	{"account_id":"1","username":"reporting","db":"shop","home":"mysql-a.dreamhost.com","host":"%",
+	 "select_priv":"Y","insert_priv":"N","update_priv":"N","delete_priv":"N","create_priv":"N","drop_priv":"N",
+	 "index_priv":"N","alter_priv":"N"},
+	{"account_id":"1","username":"reporting","db":"analytics","home":"mysql-a.dreamhost.com","host":"%",
+	 "select_priv":"1","insert_priv":"0","update_priv":"0","delete_priv":"0","create_priv":"0","drop_priv":"0",
+	 "index_priv":"0","alter_priv":"0"},
+	{"account_id":"1","username":"blog","db":"blog","home":"mysql-b.dreamhost.com","host":"%",
+	 "select_priv":"Y","insert_priv":"Y","update_priv":"Y","delete_priv":"Y","create_priv":"Y","drop_priv":"Y",
+	 "index_priv":"Y","alter_priv":"Y"}
+]`
+)
+
+func TestDataSourceMySQLDatabasesRead(t *testing.T) {
+	t.Parallel()
+
+	caller := newFakeCommandCaller()
+	caller.Respond(listMySQLDatabasesCmd, testMySQLDatabasesResponse)
+	client := newFakeCommandClient(caller)
+
+	d := schema.TestResourceDataRaw(t, dataSourceMySQLDatabases().Schema, map[string]interface{}{
+		"home": "mysql-a",
+	})
+	diags := dataSourceMySQLDatabasesRead(context.Background(), d, client)
+	require.False(t, diags.HasError(), "%v", diags)
+	assert.Equal(t, []interface{}{"analytics", "shop"}, d.Get("names"))
+	assert.Equal(t, 12.5, d.Get("databases.1.disk_usage_mb"))
+	assert.Equal(t, 0.0, d.Get("databases.0.disk_usage_mb"))
+
+	databases, err := listMySQLDatabases(context.Background(), client, "", "blog")
+	require.NoError(t, err)
+	require.Len(t, databases, 1)
+	assert.Equal(t, "mysql-b.dreamhost.com", databases[0].Home)
+
+	// API errors keep their code through the added context
+	caller.Fail(listMySQLDatabasesCmd, &commandError{Command: listMySQLDatabasesCmd, Code: "internal_error"})
+	client.cache.InvalidateCommands()
+	_, err = listMySQLDatabases(context.Background(), client, "", "")
+	assert.EqualError(t, err, "failed to list MySQL databases: mysql-list_dbs failed - response: internal_error")
+	assert.True(t, isCommandError(err, "internal_error"))
+}
+
+func TestDataSourceMySQLUsersRead(t *testing.T) {
+	t.Parallel()
+
+	caller := newFakeCommandCaller()
+	caller.Respond(listMySQLUsersCmd, testMySQLUsersResponse)
+	client := newFakeCommandClient(caller)
+
+	d := schema.TestResourceDataRaw(t, dataSourceMySQLUsers().Schema, map[string]interface{}{
+		"home": "mysql-a.dreamhost.com",
+	})
+	diags := dataSourceMySQLUsersRead(context.Background(), d, client)
+	require.False(t, diags.HasError(), "%v", diags)
+	assert.Equal(t, []interface{}{"reporting", "shop_app"}, d.Get("usernames"))
+	assert.Equal(t, 3, d.Get("users.#"))
+	assert.Equal(t, "analytics", d.Get("users.0.database"))
+	assert.Equal(t, []interface{}{"select"}, d.Get("users.0.privileges"))
+	assert.Equal(t, []interface{}{"select", "insert", "update", "delete"}, d.Get("users.2.privileges"))
+	assert.Equal(t, "%.example.com", d.Get("users.2.host"))
+	assert.NotEmpty(t, d.Id())
+
+	users, err := listMySQLUsers(context.Background(), client, "", "shop", "reporting")
+	require.NoError(t, err)
+	require.Len(t, users, 1)
+	assert.Equal(t, "%", users[0].Host)
+}
+
+func TestMySQLUserParams(t *testing.T) {
+	t.Parallel()
+
+	params := mysqlUserParams("shop", "shop_app", "s3cret", []string{"%.example.com", "10.0.0.1"}, []string{"select", "insert"})
+	assert.Equal(t, "%.example.com\n10.0.0.1", params.Get("hostnames"))
+	assert.Equal(t, "s3cret", params.Get("password"))
+	assert.Equal(t, "Y", params.Get("select"))
+	assert.Equal(t, "Y", params.Get("insert"))
+	assert.Equal(t, "N", params.Get("drop"))
+
+	database, username, err := idToMySQLUser(mysqlUserToID("shop", "shop_app"))
+	require.NoError(t, err)
+	assert.Equal(t, []string{"shop", "shop_app"}, []string{database, username})
+	_, _, err = idToMySQLUser("shop_app")
+	assert.Error(t, err)
+}
+
+func TestResourceMySQLUserPassword(t *testing.T) {
+	t.Parallel()
+
+	res := resourceMySQLUser()
+	config := map[string]interface{}{"database": "shop", "username": "shop_app", "password": "s3cret"}
+
+	// only a placeholder is planned and stored, while Create reads the configured password
+	d := schema.TestResourceDataRaw(t, res.Schema, config)
+	assert.Equal(t, "s3cret", d.Get("password"))
+	d.SetId("shop|shop_app")
+	assert.Equal(t, mysqlPasswordStateValue, d.State().Attributes["password"])
+	for key, value := range d.State().Attributes {
+		assert.NotContains(t, value, "s3cret", key)
+	}
+
+	state := &terraform.InstanceState{
+		ID: "shop|shop_app",
+		Attributes: map[string]string{
+			"id":               "shop|shop_app",
+			"database":         "shop",
+			"username":         "shop_app",
+			"password":         mysqlPasswordStateValue,
+			"password_version": "1",
+			"hostnames.#":      "0",
+			"privileges.#":     "0",
+		},
+	}
+
+	// a new password alone does not change the user
+	changed := map[string]interface{}{
+		"database": "shop", "username": "shop_app", "password": "n3w", "password_version": "1",
+	}
+	diff, err := res.Diff(context.Background(), state, terraform.NewResourceConfigRaw(changed), nil)
+	require.NoError(t, err)
+	assert.True(t, diff == nil || diff.Attributes["password"] == nil, "%v", diff)
+
+	// bumping the version replaces the user with the new password
+	changed["password_version"] = "2"
+	diff, err = res.Diff(context.Background(), state, terraform.NewResourceConfigRaw(changed), nil)
+	require.NoError(t, err)
+	require.NotNil(t, diff)
+	require.NotNil(t, diff.Attributes["password_version"])
+	assert.True(t, diff.Attributes["password_version"].RequiresNew)
+	require.NotNil(t, diff.Attributes["password"])
+	assert.Equal(t, mysqlPasswordStateValue, diff.Attributes["password"].New)
+	assert.Equal(t, "n3w", diff.Attributes["password"].NewExtra)
+}
+
+func TestResourceMySQLUserRead(t *testing.T) {
+	t.Parallel()
+
+	caller := newFakeCommandCaller()
+	caller.Respond(listMySQLUsersCmd, testMySQLUsersResponse)
+	client := newFakeCommandClient(caller)
+
+	d := schema.TestResourceDataRaw(t, resourceMySQLUser().Schema, map[string]interface{}{})
+	d.SetId("shop|shop_app")
+	diags := resourceMySQLUserRead(context.Background(), d, client)
+	require.False(t, diags.HasError(), "%v", diags)
+	assert.Equal(t, "shop_app", d.Get("username"))
+	assert.Equal(t, "mysql-a.dreamhost.com", d.Get("home"))
+	assert.ElementsMatch(t, []interface{}{"%.example.com"}, d.Get("hostnames").(*schema.Set).List())
+	assert.ElementsMatch(t, []interface{}{"select", "insert", "update", "delete"}, d.Get("privileges").(*schema.Set).List())
+
+	// existing users must be imported rather than added again
+	existing := schema.TestResourceDataRaw(t, resourceMySQLUser().Schema, map[string]interface{}{
+		"database": "analytics", "username": "reporting", "password": "s3cret",
+	})
+	diags = resourceMySQLUserCreate(context.Background(), existing, client)
+	require.True(t, diags.HasError())
+	assert.Contains(t, diags[0].Summary, `import it with ID "analytics|reporting"`)
+
+	// users removed outside of Terraform are dropped from state
+	missing := schema.TestResourceDataRaw(t, resourceMySQLUser().Schema, map[string]interface{}{})
+	missing.SetId("shop|gone")
+	diags = resourceMySQLUserRead(context.Background(), missing, client)
+	require.False(t, diags.HasError(), "%v", diags)
+	assert.Empty(t, missing.Id())
+}
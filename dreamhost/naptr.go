@@ -0,0 +1,135 @@
+package dreamhost
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+const (
+	naptrFields = 6
+)
+
+// naptrRecord is the structured form of a NAPTR record value (RFC 3403)
+type naptrRecord struct {
+	Order       int
+	Preference  int
+	Flags       string
+	Service     string
+	Regexp      string
+	Replacement string
+}
+
+// String renders the record in zone file presentation format, quoting the
+// character-string fields
+func (n naptrRecord) String() string {
+	replacement := n.Replacement
+	if replacement == "" {
+		replacement = "."
+	}
+	return fmt.Sprintf("%d %d %s %s %s %s", n.Order, n.Preference,
+		quoteCharacterString(n.Flags), quoteCharacterString(n.Service), quoteCharacterString(n.Regexp), replacement)
+}
+
+// parseNAPTRRecord parses a NAPTR value in presentation format
+func parseNAPTRRecord(value string) (*naptrRecord, error) {
+	fields, err := splitCharacterStrings(value)
+	if err != nil {
+		return nil, err
+	}
+	if len(fields) != naptrFields {
+		return nil, fmt.Errorf(
+			"NAPTR record must be in format 'order preference \"flags\" \"service\" \"regexp\" replacement', got: %s", value,
+		)
+	}
+
+	order, err := strconv.ParseUint(fields[0], 10, 16)
+	if err != nil {
+		return nil, fmt.Errorf("NAPTR order must be a number between 0 and 65535, got: %s", fields[0])
+	}
+	preference, err := strconv.ParseUint(fields[1], 10, 16)
+	if err != nil {
+		return nil, fmt.Errorf("NAPTR preference must be a number between 0 and 65535, got: %s", fields[1])
+	}
+
+	return &naptrRecord{
+		Order:       int(order),
+		Preference:  int(preference),
+		Flags:       fields[2],
+		Service:     fields[3],
+		Regexp:      fields[4],
+		Replacement: fields[5],
+	}, nil
+}
+
+// validate checks the constraints RFC 3403 puts on the fields
+func (n naptrRecord) validate() error {
+	for _, ch := range n.Flags {
+		if ch > 0x7f || !isAlphaNum(byte(ch)) {
+			return fmt.Errorf("NAPTR flags must be alphanumeric, got: %s", n.Flags)
+		}
+	}
+	if n.Replacement != "" && n.Replacement != "." {
+		if !isValidDomainName(n.Replacement) {
+			return fmt.Errorf("NAPTR replacement is not a valid domain name: %s", n.Replacement)
+		}
+		if n.Regexp != "" {
+			return fmt.Errorf("NAPTR regexp and replacement are mutually exclusive, set replacement to '.' to use a regexp")
+		}
+	}
+	return nil
+}
+
+// quoteCharacterString renders a DNS character-string in double quotes,
+// escaping quotes and backslashes
+func quoteCharacterString(s string) string {
+	return `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(s) + `"`
+}
+
+// splitCharacterStrings splits a record value into whitespace separated fields,
+// unquoting double-quoted character-strings and resolving backslash escapes
+func splitCharacterStrings(value string) ([]string, error) {
+	var (
+		fields  []string
+		current strings.Builder
+		inField bool
+		quoted  bool
+	)
+
+	for i := 0; i < len(value); i++ {
+		ch := value[i]
+		switch {
+		case ch == '\\':
+			if i+1 >= len(value) {
+				return nil, fmt.Errorf("dangling escape at the end of %q", value)
+			}
+			i++
+			current.WriteByte(value[i])
+			inField = true
+		case ch == '"':
+			if quoted {
+				quoted = false
+				continue
+			}
+			quoted = true
+			inField = true
+		case (ch == ' ' || ch == '\t') && !quoted:
+			if inField {
+				fields = append(fields, current.String())
+				current.Reset()
+				inField = false
+			}
+		default:
+			current.WriteByte(ch)
+			inField = true
+		}
+	}
+
+	if quoted {
+		return nil, fmt.Errorf("unterminated quoted string in %q", value)
+	}
+	if inField {
+		fields = append(fields, current.String())
+	}
+	return fields, nil
+}
@@ -0,0 +1,83 @@
+package dreamhost
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNAPTRRecord_RoundTrip(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		naptr    naptrRecord
+		expected string
+	}{
+		{
+			"replacement",
+			naptrRecord{Order: 100, Preference: 10, Flags: "S", Service: "SIP+D2U", Replacement: "_sip._udp.example.com"},
+			`100 10 "S" "SIP+D2U" "" _sip._udp.example.com`,
+		},
+		{
+			"regexp",
+			naptrRecord{Order: 10, Preference: 100, Flags: "u", Service: "E2U+sip", Regexp: `!^\+1(.*)$!sip:\1@example.com!`},
+			`10 100 "u" "E2U+sip" "!^\\+1(.*)$!sip:\\1@example.com!" .`,
+		},
+		{
+			"quotes",
+			naptrRecord{Service: `say "hi"`, Replacement: "."},
+			`0 0 "" "say \"hi\"" "" .`,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, tt.expected, tt.naptr.String())
+
+			parsed, err := parseNAPTRRecord(tt.expected)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, parsed.String())
+			assert.Equal(t, tt.naptr.Regexp, parsed.Regexp)
+			assert.Equal(t, tt.naptr.Service, parsed.Service)
+		})
+	}
+}
+
+func TestParseNAPTRRecord_Errors(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name  string
+		value string
+	}{
+		{"too_few_fields", `100 10 "S" "SIP+D2U" ""`},
+		{"order_not_number", `x 10 "S" "SIP+D2U" "" .`},
+		{"preference_too_large", `100 65536 "S" "SIP+D2U" "" .`},
+		{"unterminated_quote", `100 10 "S "SIP+D2U" "" .`},
+		{"dangling_escape", `100 10 "S" "SIP+D2U" "" .\`},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			_, err := parseNAPTRRecord(tt.value)
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestNAPTRRecord_Validate(t *testing.T) {
+	t.Parallel()
+
+	assert.NoError(t, naptrRecord{Flags: "SU", Replacement: "."}.validate())
+	assert.Error(t, naptrRecord{Flags: "S!", Replacement: "."}.validate())
+	assert.Error(t, naptrRecord{Regexp: "!a!b!", Replacement: "example.com"}.validate())
+	assert.Error(t, naptrRecord{Replacement: "bad domain"}.validate())
+}
@@ -0,0 +1,229 @@
+package dreamhost
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	listPrivateServerUsageCmd         = "dreamhost_ps-list_usage"
+	listPrivateServerSizeHistoryCmd   = "dreamhost_ps-list_size_history"
+	listPrivateServerRebootHistoryCmd = "dreamhost_ps-list_reboot_history"
+	listPrivateServerImagesCmd        = "dreamhost_ps-list_images"
+
+	defaultAPITimezone = "UTC"
+	// apiTimestampKey is the column the dreamhost_ps history listings report times in
+	apiTimestampKey = "stamp"
+)
+
+// apiTimestampLayouts are the formats the API reports times in; times without an
+// offset are interpreted in the configured timezone
+// nolint:gochecknoglobals
+var apiTimestampLayouts = []string{time.RFC3339, "2006-01-02 15:04:05", "2006-01-02T15:04:05", "2006-01-02"}
+
+// listPrivateServerRecords returns the entries of a dreamhost_ps listing as string
+// maps; plain string entries, as some commands return, are stored under plainKey
+func listPrivateServerRecords(
+	ctx context.Context, api *cachedDreamhostClient, command string, params url.Values, plainKey string,
+) ([]map[string]string, error) {
+	var data json.RawMessage
+	if err := api.CallCachedCommand(ctx, command, params, &data); err != nil {
+		return nil, errors.Wrapf(err, "failed to call %s", command)
+	}
+
+	var entries []interface{}
+	if err := json.Unmarshal(data, &entries); err != nil {
+		return nil, errors.Wrapf(err, "failed to parse %s response", command)
+	}
+
+	records := make([]map[string]string, 0, len(entries))
+	for _, entry := range entries {
+		record := map[string]string{}
+		switch entry := entry.(type) {
+		case map[string]interface{}:
+			for key, value := range entry {
+				switch value := value.(type) {
+				case string:
+					record[key] = value
+				case nil:
+				default:
+					encoded, _ := json.Marshal(value)
+					record[key] = string(encoded)
+				}
+			}
+		case string:
+			record[plainKey] = entry
+		default:
+			return nil, fmt.Errorf("unexpected %s entry: %v", command, entry)
+		}
+		records = append(records, record)
+	}
+
+	return records, nil
+}
+
+// parseAPITimestamp converts an API time to RFC 3339 in UTC
+func parseAPITimestamp(value string, location *time.Location) (string, error) {
+	value = strings.TrimSpace(value)
+	for _, layout := range apiTimestampLayouts {
+		if parsed, err := time.ParseInLocation(layout, value, location); err == nil {
+			return parsed.UTC().Format(time.RFC3339), nil
+		}
+	}
+	if seconds, err := strconv.ParseInt(value, 10, 64); err == nil {
+		return time.Unix(seconds, 0).UTC().Format(time.RFC3339), nil
+	}
+	return "", fmt.Errorf("could not parse timestamp %q", value)
+}
+
+// recordTimestamp returns the time of a listing entry in RFC 3339
+func recordTimestamp(record map[string]string, location *time.Location) (string, error) {
+	value, ok := record[apiTimestampKey]
+	if !ok || value == "" {
+		return "", fmt.Errorf("entry has no timestamp (%s): %v", apiTimestampKey, record)
+	}
+	return parseAPITimestamp(value, location)
+}
+
+// recordFloat returns the number in a column of a listing entry; a missing
+// column means the response is not the documented one, so it is not read as 0
+func recordFloat(record map[string]string, key string) (float64, error) {
+	value, ok := record[key]
+	if !ok {
+		return 0, fmt.Errorf("entry has no %s: %v", key, record)
+	}
+	number, err := strconv.ParseFloat(strings.TrimSpace(value), 64)
+	if err != nil {
+		return 0, fmt.Errorf("entry has an invalid %s %q", key, value)
+	}
+	return number, nil
+}
+
+// recordFloats returns the numbers in the columns of a listing entry by column
+func recordFloats(record map[string]string, keys ...string) (map[string]float64, error) {
+	numbers := make(map[string]float64, len(keys))
+	for _, key := range keys {
+		number, err := recordFloat(record, key)
+		if err != nil {
+			return nil, err
+		}
+		numbers[key] = number
+	}
+	return numbers, nil
+}
+
+// loadAPITimezone returns the location times without an offset are interpreted in
+func loadAPITimezone(name string) (*time.Location, error) {
+	if name == "" {
+		name = defaultAPITimezone
+	}
+	location, err := time.LoadLocation(name)
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid timezone %q", name)
+	}
+	return location, nil
+}
+
+// sortByTimestamp orders time series entries from oldest to newest; RFC 3339
+// times in UTC sort as strings
+func sortByTimestamp(points []map[string]interface{}) {
+	sort.SliceStable(points, func(i, j int) bool {
+		left, _ := points[i]["timestamp"].(string)
+		right, _ := points[j]["timestamp"].(string)
+		return left < right
+	})
+}
+
+// privateServerUsage returns the usage samples of the server, oldest first
+func privateServerUsage(
+	ctx context.Context, api *cachedDreamhostClient, name string, location *time.Location,
+) ([]map[string]interface{}, error) {
+	params := privateServerParams(name)
+	records, err := listPrivateServerRecords(ctx, api, listPrivateServerUsageCmd, params, apiTimestampKey)
+	if err != nil {
+		return nil, err
+	}
+
+	points := make([]map[string]interface{}, 0, len(records))
+	for _, record := range records {
+		timestamp, err := recordTimestamp(record, location)
+		if err != nil {
+			return nil, err
+		}
+		numbers, err := recordFloats(record, "memory_mb", "load")
+		if err != nil {
+			return nil, errors.Wrapf(err, "unexpected %s response", listPrivateServerUsageCmd)
+		}
+		points = append(points, map[string]interface{}{
+			"timestamp": timestamp,
+			"memory_mb": numbers["memory_mb"],
+			"load":      numbers["load"],
+		})
+	}
+	sortByTimestamp(points)
+
+	return points, nil
+}
+
+// privateServerResizes returns the size history of the server, oldest first
+func privateServerResizes(
+	ctx context.Context, api *cachedDreamhostClient, name string, location *time.Location,
+) ([]map[string]interface{}, error) {
+	params := privateServerParams(name)
+	records, err := listPrivateServerRecords(ctx, api, listPrivateServerSizeHistoryCmd, params, apiTimestampKey)
+	if err != nil {
+		return nil, err
+	}
+
+	points := make([]map[string]interface{}, 0, len(records))
+	for _, record := range records {
+		timestamp, err := recordTimestamp(record, location)
+		if err != nil {
+			return nil, err
+		}
+		numbers, err := recordFloats(record, "memory_mb", "period_cost", "monthly_cost")
+		if err != nil {
+			return nil, errors.Wrapf(err, "unexpected %s response", listPrivateServerSizeHistoryCmd)
+		}
+		points = append(points, map[string]interface{}{
+			"timestamp":    timestamp,
+			"memory_mb":    int(numbers["memory_mb"]),
+			"period_cost":  numbers["period_cost"],
+			"monthly_cost": numbers["monthly_cost"],
+		})
+	}
+	sortByTimestamp(points)
+
+	return points, nil
+}
+
+// privateServerReboots returns the reboot times of the server, oldest first
+func privateServerReboots(
+	ctx context.Context, api *cachedDreamhostClient, name string, location *time.Location,
+) ([]string, error) {
+	params := privateServerParams(name)
+	records, err := listPrivateServerRecords(ctx, api, listPrivateServerRebootHistoryCmd, params, apiTimestampKey)
+	if err != nil {
+		return nil, err
+	}
+
+	reboots := make([]string, 0, len(records))
+	for _, record := range records {
+		timestamp, err := recordTimestamp(record, location)
+		if err != nil {
+			return nil, err
+		}
+		reboots = append(reboots, timestamp)
+	}
+	sort.Strings(reboots)
+
+	return reboots, nil
+}
@@ -0,0 +1,157 @@
+package dreamhost
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// The fixtures follow the columns the API documentation lists for each command:
+// dreamhost_ps-list_usage reports ps, stamp, memory_mb and load, and
+// dreamhost_ps-list_size_history reports ps, stamp, memory_mb, period_cost and monthly_cost.
+const (
+	testPrivateServerUsageResponse = `[
+		{"ps":"ps10001","stamp":"2024-01-02 01:00:00","memory_mb":"812.5","load":"0.40"},
+		{"ps":"ps10001","stamp":"2024-01-02 00:00:00","memory_mb":"640","load":"1.25"}
+	]`
+	testPrivateServerSizeHistoryResponse = `[
+		{"ps":"ps10001","stamp":"2023-06-01 12:00:00","memory_mb":"2048","period_cost":"10.5","monthly_cost":"60"},
+		{"ps":"ps10001","stamp":"2023-01-01 12:00:00","memory_mb":"1024","period_cost":"45","monthly_cost":"30"}
+	]`
+)
+
+func TestParseAPITimestamp(t *testing.T) {
+	t.Parallel()
+
+	pacific := time.FixedZone("PST", -8*60*60)
+
+	tests := []struct {
+		name     string
+		value    string
+		location *time.Location
+		expected string
+		wantErr  bool
+	}{
+		{"utc datetime", "2024-01-02 03:04:05", time.UTC, "2024-01-02T03:04:05Z", false},
+		{"local datetime", "2024-01-02 03:04:05", pacific, "2024-01-02T11:04:05Z", false},
+		{"rfc3339 keeps its offset", "2024-01-02T03:04:05+01:00", pacific, "2024-01-02T02:04:05Z", false},
+		{"date", "2024-01-02", time.UTC, "2024-01-02T00:00:00Z", false},
+		{"unix seconds", "1704164645", pacific, "2024-01-02T03:04:05Z", false},
+		{"invalid", "yesterday", time.UTC, "", true},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got, err := parseAPITimestamp(tt.value, tt.location)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, got)
+		})
+	}
+}
+
+func TestDataSourcePrivateServerUsageRead(t *testing.T) {
+	t.Parallel()
+
+	caller := newFakeCommandCaller()
+	caller.Respond(listPrivateServerUsageCmd+"?ps=ps10001", testPrivateServerUsageResponse)
+	client := newFakeCommandClient(caller)
+
+	d := schema.TestResourceDataRaw(t, dataSourcePrivateServerUsage().Schema, map[string]interface{}{"ps": "ps10001"})
+	diags := dataSourcePrivateServerUsageRead(context.Background(), d, client)
+	require.False(t, diags.HasError(), "%v", diags)
+	assert.Equal(t, 2, d.Get("usage.#"))
+	assert.Equal(t, "2024-01-02T00:00:00Z", d.Get("usage.0.timestamp"))
+	assert.Equal(t, 1.25, d.Get("usage.0.load"))
+	assert.Equal(t, 812.5, d.Get("peak_memory_mb"))
+	assert.Equal(t, 1.25, d.Get("peak_load"))
+	assert.NotEmpty(t, d.Id())
+
+	invalid := schema.TestResourceDataRaw(t, dataSourcePrivateServerUsage().Schema, map[string]interface{}{
+		"ps": "ps10001", "timezone": "Not/AZone",
+	})
+	diags = dataSourcePrivateServerUsageRead(context.Background(), invalid, client)
+	require.True(t, diags.HasError())
+	assert.Contains(t, diags[0].Summary, `invalid timezone "Not/AZone"`)
+
+	// columns other than the documented ones are reported rather than read as 0
+	caller.Respond(listPrivateServerUsageCmd+"?ps=ps10002", `[{"ps":"ps10002","stamp":"2024-01-02 00:00:00","cpu":"1"}]`)
+	renamed := schema.TestResourceDataRaw(t, dataSourcePrivateServerUsage().Schema, map[string]interface{}{"ps": "ps10002"})
+	diags = dataSourcePrivateServerUsageRead(context.Background(), renamed, client)
+	require.True(t, diags.HasError())
+	assert.Contains(t, diags[0].Summary, "entry has no memory_mb")
+}
+
+func TestDataSourcePrivateServerHistoryRead(t *testing.T) {
+	t.Parallel()
+
+	caller := newFakeCommandCaller()
+	caller.Respond(listPrivateServerSizeHistoryCmd+"?ps=ps10001", testPrivateServerSizeHistoryResponse)
+	caller.Respond(listPrivateServerRebootHistoryCmd+"?ps=ps10001", `["2023-07-04 08:00:00","2023-03-01 22:15:00"]`)
+	client := newFakeCommandClient(caller)
+
+	d := schema.TestResourceDataRaw(t, dataSourcePrivateServerHistory().Schema, map[string]interface{}{"ps": "ps10001"})
+	diags := dataSourcePrivateServerHistoryRead(context.Background(), d, client)
+	require.False(t, diags.HasError(), "%v", diags)
+	assert.Equal(t, 1024, d.Get("resizes.0.memory_mb"))
+	assert.Equal(t, "2023-06-01T12:00:00Z", d.Get("resizes.1.timestamp"))
+	assert.Equal(t, 60.0, d.Get("resizes.1.monthly_cost"))
+	assert.Equal(t, []interface{}{"2023-03-01T22:15:00Z", "2023-07-04T08:00:00Z"}, d.Get("reboots"))
+	assert.Equal(t, "2023-07-04T08:00:00Z", d.Get("last_reboot"))
+
+	// entries without a time cannot be placed in the series
+	caller.Respond(listPrivateServerRebootHistoryCmd+"?ps=ps10002", `[{"ps":"ps10002"}]`)
+	caller.Respond(listPrivateServerSizeHistoryCmd+"?ps=ps10002", `[]`)
+	broken := schema.TestResourceDataRaw(t, dataSourcePrivateServerHistory().Schema, map[string]interface{}{"ps": "ps10002"})
+	diags = dataSourcePrivateServerHistoryRead(context.Background(), broken, client)
+	require.True(t, diags.HasError())
+	assert.Contains(t, diags[0].Summary, "entry has no timestamp")
+
+	caller.Respond(listPrivateServerRebootHistoryCmd+"?ps=ps10003", `[]`)
+	caller.Respond(listPrivateServerSizeHistoryCmd+"?ps=ps10003",
+		`[{"ps":"ps10003","stamp":"2023-01-01 12:00:00","memory_mb":"1024","cost":"30"}]`)
+	renamed := schema.TestResourceDataRaw(t, dataSourcePrivateServerHistory().Schema, map[string]interface{}{"ps": "ps10003"})
+	diags = dataSourcePrivateServerHistoryRead(context.Background(), renamed, client)
+	require.True(t, diags.HasError())
+	assert.Contains(t, diags[0].Summary, "entry has no period_cost")
+}
+
+func TestDataSourcePrivateServerImagesRead(t *testing.T) {
+	t.Parallel()
+
+	caller := newFakeCommandCaller()
+	caller.Respond(listPrivateServerImagesCmd, `[
+		{"image":"ubuntu-22.04","description":"Ubuntu 22.04","size_gb":20},
+		{"image":"debian-12","description":"Debian 12","size_gb":10}
+	]`)
+	caller.Respond(listPrivateServerImagesCmd+"?ps=ps10001", `["ubuntu-22.04"]`)
+	client := newFakeCommandClient(caller)
+
+	d := schema.TestResourceDataRaw(t, dataSourcePrivateServerImages().Schema, map[string]interface{}{})
+	diags := dataSourcePrivateServerImagesRead(context.Background(), d, client)
+	require.False(t, diags.HasError(), "%v", diags)
+	assert.Equal(t, []interface{}{"debian-12", "ubuntu-22.04"}, d.Get("names"))
+	assert.Equal(t, "Debian 12", d.Get("images.0.details.description"))
+	assert.Equal(t, "10", d.Get("images.0.details.size_gb"))
+
+	scoped := schema.TestResourceDataRaw(t, dataSourcePrivateServerImages().Schema, map[string]interface{}{"ps": "ps10001"})
+	diags = dataSourcePrivateServerImagesRead(context.Background(), scoped, client)
+	require.False(t, diags.HasError(), "%v", diags)
+	assert.Equal(t, []interface{}{"ubuntu-22.04"}, scoped.Get("names"))
+
+	// entries without the documented image column are reported rather than named ""
+	caller.Respond(listPrivateServerImagesCmd+"?ps=ps10002", `[{"name":"ubuntu-22.04"}]`)
+	renamed := schema.TestResourceDataRaw(t, dataSourcePrivateServerImages().Schema, map[string]interface{}{"ps": "ps10002"})
+	diags = dataSourcePrivateServerImagesRead(context.Background(), renamed, client)
+	require.True(t, diags.HasError())
+	assert.Contains(t, diags[0].Summary, "entry has no image")
+}
@@ -0,0 +1,352 @@
+package dreamhost
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/pkg/errors"
+)
+
+const (
+	addPrivateServerCmd          = "dreamhost_ps-add_ps"
+	listPrivateServersCmd        = "dreamhost_ps-list_ps"
+	listPendingPrivateServersCmd = "dreamhost_ps-list_pending_ps"
+	setPrivateServerSizeCmd      = "dreamhost_ps-set_size"
+	removePrivateServerCmd       = "dreamhost_ps-remove_ps"
+	listPrivateServerSettingsCmd = "dreamhost_ps-list_settings"
+	setPrivateServerSettingsCmd  = "dreamhost_ps-set_settings"
+
+	// privateServerNotFoundCode is returned when removing a server that does not exist
+	privateServerNotFoundCode = "no_such_ps"
+
+	// provisioning and resizing private servers takes minutes rather than seconds
+	defaultPrivateServerTimeout = 30 * time.Minute
+	privateServerPollDelay      = 30 * time.Second
+)
+
+// privateServerTypes are the server types dreamhost_ps-add_ps accepts
+// nolint:gochecknoglobals
+var privateServerTypes = []string{"web", "mysql"}
+
+// privateServer is a server as returned by dreamhost_ps-list_ps
+type privateServer struct {
+	AccountID   string `json:"account_id"`
+	Name        string `json:"ps"`
+	Description string `json:"description"`
+	Status      string `json:"status"`
+	Type        string `json:"type"`
+	MemoryMB    string `json:"memory_mb"`
+	StartDate   string `json:"start_date"`
+	IP          string `json:"ip"`
+}
+
+// memory returns the memory size in megabytes
+func (s privateServer) memory() int {
+	memory, _ := strconv.Atoi(s.MemoryMB)
+	return memory
+}
+
+// pendingPrivateServer is a request as returned by dreamhost_ps-list_pending_ps
+type pendingPrivateServer struct {
+	AccountID string `json:"account_id"`
+	IP        string `json:"ip"`
+	Type      string `json:"type"`
+}
+
+func listPrivateServers(ctx context.Context, api *cachedDreamhostClient) ([]privateServer, error) {
+	var servers []privateServer
+	if err := api.CallCachedCommand(ctx, listPrivateServersCmd, nil, &servers); err != nil {
+		return nil, errors.Wrap(err, "failed to list private servers")
+	}
+	return servers, nil
+}
+
+func listPendingPrivateServers(ctx context.Context, api *cachedDreamhostClient) ([]pendingPrivateServer, error) {
+	var pending []pendingPrivateServer
+	if err := api.CallCachedCommand(ctx, listPendingPrivateServersCmd, nil, &pending); err != nil {
+		return nil, errors.Wrap(err, "failed to list pending private servers")
+	}
+	return pending, nil
+}
+
+// findPrivateServer looks the server up in the cached listing
+func findPrivateServer(ctx context.Context, api *cachedDreamhostClient, name string) (*privateServer, error) {
+	servers, err := listPrivateServers(ctx, api)
+	if err != nil {
+		return nil, err
+	}
+	for i := range servers {
+		if servers[i].Name == name {
+			return &servers[i], nil
+		}
+	}
+	return nil, nil
+}
+
+// privateServerNames returns the names of the servers of the account
+func privateServerNames(ctx context.Context, api *cachedDreamhostClient) ([]string, error) {
+	servers, err := listPrivateServers(ctx, api)
+	if err != nil {
+		return nil, err
+	}
+	names := make([]string, 0, len(servers))
+	for _, server := range servers {
+		names = append(names, server.Name)
+	}
+	return names, nil
+}
+
+// waitForPrivateServer waits for a server of the type that is not one of the
+// known servers to show up in the API; the API does not return the name of a
+// requested server
+func waitForPrivateServer(
+	ctx context.Context, client *cachedDreamhostClient, serverType string, known []string, timeout time.Duration,
+) (*privateServer, error) {
+	stateConf := &resource.StateChangeConf{
+		Pending:    []string{"pending"},
+		Target:     []string{"identified"},
+		Refresh:    privateServerStateRefreshFunc(ctx, client, serverType, known),
+		Timeout:    timeout,
+		Delay:      retryDelay,
+		MinTimeout: privateServerPollDelay,
+	}
+
+	result, err := stateConf.WaitForStateContext(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "error waiting for private server")
+	}
+
+	server, ok := result.(*privateServer)
+	if !ok || server == nil {
+		return nil, fmt.Errorf("unexpected type from state refresh: %T", result)
+	}
+
+	return server, nil
+}
+
+// waitForPrivateServerProvisioning waits for the request of a listed server to
+// leave the pending list
+func waitForPrivateServerProvisioning(
+	ctx context.Context, client *cachedDreamhostClient, server *privateServer, timeout time.Duration,
+) error {
+	stateConf := &resource.StateChangeConf{
+		Pending:    []string{"provisioning"},
+		Target:     []string{"provisioned"},
+		Refresh:    privateServerProvisioningStateRefreshFunc(ctx, client, server),
+		Timeout:    timeout,
+		MinTimeout: privateServerPollDelay,
+	}
+
+	if _, err := stateConf.WaitForStateContext(ctx); err != nil {
+		return errors.Wrapf(err, "error waiting for private server %s to be provisioned", server.Name)
+	}
+
+	return nil
+}
+
+// waitForPrivateServerSize waits for the server to report the memory size
+func waitForPrivateServerSize(
+	ctx context.Context, client *cachedDreamhostClient, name string, memory int, timeout time.Duration,
+) error {
+	stateConf := &resource.StateChangeConf{
+		Pending:    []string{"resizing"},
+		Target:     []string{"resized"},
+		Refresh:    privateServerSizeStateRefreshFunc(ctx, client, name, memory),
+		Timeout:    timeout,
+		Delay:      retryDelay,
+		MinTimeout: privateServerPollDelay,
+	}
+
+	if _, err := stateConf.WaitForStateContext(ctx); err != nil {
+		return errors.Wrap(err, "error waiting for private server resize")
+	}
+
+	return nil
+}
+
+// waitForPrivateServerDeletion waits for a private server to disappear from the API
+func waitForPrivateServerDeletion(
+	ctx context.Context, client *cachedDreamhostClient, name string, timeout time.Duration,
+) error {
+	stateConf := &resource.StateChangeConf{
+		Pending:    []string{"deleting"},
+		Target:     []string{"deleted"},
+		Refresh:    privateServerDeletionStateRefreshFunc(ctx, client, name),
+		Timeout:    timeout,
+		Delay:      retryDelay,
+		MinTimeout: privateServerPollDelay,
+	}
+
+	if _, err := stateConf.WaitForStateContext(ctx); err != nil {
+		return errors.Wrap(err, "error waiting for private server deletion")
+	}
+
+	return nil
+}
+
+// privateServerStateRefreshFunc returns a function that checks if a new server of the type exists;
+// several new servers of the type cannot be told apart, so they are reported as an error
+func privateServerStateRefreshFunc(
+	ctx context.Context, client *cachedDreamhostClient, serverType string, known []string,
+) resource.StateRefreshFunc {
+	return func() (interface{}, string, error) {
+		// Invalidate cache to get fresh data
+		client.cache.InvalidateCommands()
+
+		servers, err := listPrivateServers(ctx, client)
+		if err != nil {
+			return nil, "", err
+		}
+		var found []privateServer
+		for _, server := range servers {
+			if server.Type == serverType && !containsString(known, server.Name) {
+				found = append(found, server)
+			}
+		}
+		switch {
+		case len(found) == 1:
+			return &found[0], "identified", nil
+		case len(found) > 1:
+			names := make([]string, 0, len(found))
+			for _, server := range found {
+				names = append(names, server.Name)
+			}
+			return nil, "", fmt.Errorf(
+				"found several new %s private servers (%s), import the one that was requested",
+				serverType, strings.Join(names, ", "),
+			)
+		}
+
+		pending, err := listPendingPrivateServers(ctx, client)
+		if err != nil {
+			return nil, "", err
+		}
+		for _, request := range pending {
+			if request.Type == serverType {
+				return request, "pending", nil
+			}
+		}
+
+		// neither pending nor listed yet, the request may not have been queued
+		return serverType, "pending", nil
+	}
+}
+
+// privateServerProvisioningStateRefreshFunc returns a function that checks if the
+// request of a server is still pending; requests are matched by the IP address of
+// the server, and a server without one cannot be told apart from other requests of
+// its type, so it counts as provisioned
+func privateServerProvisioningStateRefreshFunc(
+	ctx context.Context, client *cachedDreamhostClient, server *privateServer,
+) resource.StateRefreshFunc {
+	return func() (interface{}, string, error) {
+		// Invalidate cache to get fresh data
+		client.cache.InvalidateCommands()
+
+		pending, err := listPendingPrivateServers(ctx, client)
+		if err != nil {
+			return nil, "", err
+		}
+		for _, request := range pending {
+			if server.IP != "" && request.IP == server.IP {
+				return request, "provisioning", nil
+			}
+		}
+
+		return server, "provisioned", nil
+	}
+}
+
+// privateServerSizeStateRefreshFunc returns a function that checks if a server reports the memory size
+func privateServerSizeStateRefreshFunc(
+	ctx context.Context, client *cachedDreamhostClient, name string, memory int,
+) resource.StateRefreshFunc {
+	return func() (interface{}, string, error) {
+		// Invalidate cache to get fresh data
+		client.cache.InvalidateCommands()
+
+		server, err := findPrivateServer(ctx, client, name)
+		if err != nil {
+			return nil, "", err
+		}
+		if server == nil {
+			return nil, "", fmt.Errorf("private server %s not found", name)
+		}
+
+		if server.memory() != memory {
+			return server, "resizing", nil
+		}
+
+		return server, "resized", nil
+	}
+}
+
+// privateServerDeletionStateRefreshFunc returns a function that checks if a server has been removed
+func privateServerDeletionStateRefreshFunc(
+	ctx context.Context, client *cachedDreamhostClient, name string,
+) resource.StateRefreshFunc {
+	return func() (interface{}, string, error) {
+		// Invalidate cache to get fresh data
+		client.cache.InvalidateCommands()
+
+		server, err := findPrivateServer(ctx, client, name)
+		if err != nil {
+			return nil, "", err
+		}
+
+		if server != nil {
+			return server, "deleting", nil
+		}
+
+		// a nil result would count as not found rather than reaching the target
+		return name, "deleted", nil
+	}
+}
+
+// privateServerParams identifies the server to the dreamhost_ps commands
+func privateServerParams(name string) url.Values {
+	return url.Values{"ps": {name}}
+}
+
+// privateServerSetting is a setting as listed by dreamhost_ps-list_settings
+type privateServerSetting struct {
+	Setting string `json:"setting"`
+	Value   string `json:"value"`
+}
+
+// listPrivateServerSettings returns the settings of the server
+func listPrivateServerSettings(ctx context.Context, api *cachedDreamhostClient, name string) (map[string]string, error) {
+	var data json.RawMessage
+	if err := api.CallCachedCommand(ctx, listPrivateServerSettingsCmd, privateServerParams(name), &data); err != nil {
+		return nil, errors.Wrapf(err, "failed to list settings of private server %s", name)
+	}
+	settings, err := parsePrivateServerSettings(data)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to list settings of private server %s", name)
+	}
+	return settings, nil
+}
+
+// parsePrivateServerSettings parses the setting and value rows documented for
+// dreamhost_ps-list_settings; any other shape is an error rather than no settings
+func parsePrivateServerSettings(data json.RawMessage) (map[string]string, error) {
+	var list []privateServerSetting
+	if err := json.Unmarshal(data, &list); err != nil {
+		return nil, errors.Wrapf(err, "unexpected private server settings %s", data)
+	}
+
+	settings := make(map[string]string, len(list))
+	for _, setting := range list {
+		if setting.Setting == "" {
+			return nil, errors.Errorf("unexpected private server settings %s: entry has no setting", data)
+		}
+		settings[setting.Setting] = setting.Value
+	}
+	return settings, nil
+}
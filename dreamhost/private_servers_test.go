@@ -0,0 +1,277 @@
+package dreamhost
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/url"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testPrivateServersResponse = `[
+	{"account_id":"1","ps":"ps10001","description":"web","status":"running","type":"web","memory_mb":"1024",
+	 "start_date":"2020-01-01","ip":"192.0.2.10"},
+	{"account_id":"1","ps":"ps10002","description":"db","status":"running","type":"mysql","memory_mb":"2048",
+	 "start_date":"2021-06-01","ip":"192.0.2.11"}
+]`
+
+// testPrivateServerSettingsResponse follows the setting and value rows of dreamhost_ps-list_settings
+const testPrivateServerSettingsResponse = `[
+	{"setting":"apache2_enabled","value":"0"},
+	{"setting":"nginx_enabled","value":"1"},
+	{"setting":"monitoring","value":"1"},
+	{"setting":"admin_user","value":"1"}
+]`
+
+func TestPrivateServerStateRefreshFunc(t *testing.T) {
+	t.Parallel()
+
+	caller := newFakeCommandCaller()
+	caller.Respond(listPrivateServersCmd, testPrivateServersResponse)
+	caller.Respond(listPendingPrivateServersCmd, `[{"account_id":"1","ip":"","type":"web"}]`)
+	client := newFakeCommandClient(caller)
+
+	refresh := privateServerStateRefreshFunc(context.Background(), client, "web", []string{"ps10001", "ps10002"})
+
+	_, state, err := refresh()
+	require.NoError(t, err)
+	assert.Equal(t, "pending", state)
+
+	// the new server is the one of the type that was not there before
+	caller.Respond(listPendingPrivateServersCmd, `[]`)
+	caller.Respond(listPrivateServersCmd, `[
+		{"ps":"ps10001","type":"web","memory_mb":"1024"},
+		{"ps":"ps10002","type":"mysql","memory_mb":"2048"},
+		{"ps":"ps10003","type":"web","memory_mb":"300","status":"provisioning"}
+	]`)
+	result, state, err := refresh()
+	require.NoError(t, err)
+	assert.Equal(t, "identified", state)
+	assert.Equal(t, "ps10003", result.(*privateServer).Name)
+
+	// a mysql server that is neither pending nor listed yet is still waited for
+	result, state, err = privateServerStateRefreshFunc(context.Background(), client, "mysql", []string{"ps10002"})()
+	require.NoError(t, err)
+	assert.Equal(t, "pending", state)
+	assert.NotNil(t, result)
+}
+
+func TestPrivateServerStateRefreshFuncSeveralNewServers(t *testing.T) {
+	t.Parallel()
+
+	caller := newFakeCommandCaller()
+	caller.Respond(listPendingPrivateServersCmd, `[]`)
+	caller.Respond(listPrivateServersCmd, `[
+		{"ps":"ps10001","type":"web","memory_mb":"1024"},
+		{"ps":"ps10003","type":"web","memory_mb":"300"},
+		{"ps":"ps10004","type":"web","memory_mb":"300"}
+	]`)
+	client := newFakeCommandClient(caller)
+
+	// two servers of the type appearing at once cannot be told apart
+	_, _, err := privateServerStateRefreshFunc(context.Background(), client, "web", []string{"ps10001"})()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "ps10003, ps10004")
+
+	// a known server is never taken for the new one
+	result, state, err := privateServerStateRefreshFunc(
+		context.Background(), client, "web", []string{"ps10001", "ps10003"},
+	)()
+	require.NoError(t, err)
+	assert.Equal(t, "identified", state)
+	assert.Equal(t, "ps10004", result.(*privateServer).Name)
+}
+
+func TestPrivateServerProvisioningStateRefreshFunc(t *testing.T) {
+	t.Parallel()
+
+	caller := newFakeCommandCaller()
+	caller.Respond(listPendingPrivateServersCmd, `[{"account_id":"1","ip":"192.0.2.12","type":"web"}]`)
+	client := newFakeCommandClient(caller)
+
+	_, state, err := privateServerProvisioningStateRefreshFunc(
+		context.Background(), client, &privateServer{Name: "ps10003", Type: "web", IP: "192.0.2.12"},
+	)()
+	require.NoError(t, err)
+	assert.Equal(t, "provisioning", state)
+
+	result, state, err := privateServerProvisioningStateRefreshFunc(
+		context.Background(), client, &privateServer{Name: "ps10004", Type: "web", IP: "192.0.2.13"},
+	)()
+	require.NoError(t, err)
+	assert.Equal(t, "provisioned", state)
+	assert.NotNil(t, result)
+
+	// without an IP address the pending request of another server of the type is not taken for it
+	_, state, err = privateServerProvisioningStateRefreshFunc(
+		context.Background(), client, &privateServer{Name: "ps10005", Type: "web"},
+	)()
+	require.NoError(t, err)
+	assert.Equal(t, "provisioned", state)
+}
+
+// addingCommandCaller lists a new server once dreamhost_ps-add_ps was called
+type addingCommandCaller struct {
+	*fakeCommandCaller
+	servers string
+}
+
+func (a *addingCommandCaller) Call(ctx context.Context, command string, params url.Values) (json.RawMessage, error) {
+	if command == addPrivateServerCmd {
+		a.Respond(listPrivateServersCmd, a.servers)
+	}
+	return a.fakeCommandCaller.Call(ctx, command, params)
+}
+
+func TestResourcePrivateServerCreate(t *testing.T) {
+	t.Parallel()
+
+	caller := newFakeCommandCaller()
+	caller.Respond(addPrivateServerCmd, `"success"`)
+	caller.Fail(listPendingPrivateServersCmd, errors.New("dreamhost_ps-list_pending_ps failed"))
+	client := newDreamhostClient(NewMockDreamhostClient())
+	client.commands = &addingCommandCaller{fakeCommandCaller: caller, servers: `[
+		{"ps":"ps10001","type":"web","memory_mb":"1024","ip":"192.0.2.10"},
+		{"ps":"ps10002","type":"mysql","memory_mb":"2048","ip":"192.0.2.11"},
+		{"ps":"ps10003","type":"web","memory_mb":"300","ip":"192.0.2.12"}
+	]`}
+
+	// a stale cached listing must not make existing servers look new
+	caller.Respond(listPrivateServersCmd, `[]`)
+	_, err := privateServerNames(context.Background(), client)
+	require.NoError(t, err)
+	caller.Respond(listPrivateServersCmd, testPrivateServersResponse)
+
+	d := schema.TestResourceDataRaw(t, resourcePrivateServer().Schema, map[string]interface{}{"type": "web"})
+	diags := resourcePrivateServerCreate(context.Background(), d, client)
+
+	// the server is tracked as soon as it is identified, even if provisioning fails
+	require.True(t, diags.HasError())
+	assert.Contains(t, diags[0].Summary, "provisioned")
+	assert.Equal(t, "ps10003", d.Id())
+	assert.Contains(t, caller.Calls(), addPrivateServerCmd+"?movedata=no&type=web")
+}
+
+func TestPrivateServerSizeStateRefreshFunc(t *testing.T) {
+	t.Parallel()
+
+	caller := newFakeCommandCaller()
+	caller.Respond(listPrivateServersCmd, testPrivateServersResponse)
+	client := newFakeCommandClient(caller)
+
+	_, state, err := privateServerSizeStateRefreshFunc(context.Background(), client, "ps10001", 2048)()
+	require.NoError(t, err)
+	assert.Equal(t, "resizing", state)
+
+	_, state, err = privateServerSizeStateRefreshFunc(context.Background(), client, "ps10002", 2048)()
+	require.NoError(t, err)
+	assert.Equal(t, "resized", state)
+
+	_, _, err = privateServerSizeStateRefreshFunc(context.Background(), client, "ps99999", 2048)()
+	assert.Error(t, err)
+
+	_, state, err = privateServerDeletionStateRefreshFunc(context.Background(), client, "ps10001")()
+	require.NoError(t, err)
+	assert.Equal(t, "deleting", state)
+	result, state, err := privateServerDeletionStateRefreshFunc(context.Background(), client, "ps99999")()
+	require.NoError(t, err)
+	assert.Equal(t, "deleted", state)
+	assert.NotNil(t, result)
+}
+
+func TestResourcePrivateServerRead(t *testing.T) {
+	t.Parallel()
+
+	caller := newFakeCommandCaller()
+	caller.Respond(listPrivateServersCmd, testPrivateServersResponse)
+	client := newFakeCommandClient(caller)
+
+	d := schema.TestResourceDataRaw(t, resourcePrivateServer().Schema, map[string]interface{}{})
+	d.SetId("ps10002")
+	diags := resourcePrivateServerRead(context.Background(), d, client)
+	require.False(t, diags.HasError(), "%v", diags)
+	assert.Equal(t, "mysql", d.Get("type"))
+	assert.Equal(t, 2048, d.Get("memory_mb"))
+	assert.Equal(t, "192.0.2.11", d.Get("ip"))
+	assert.Equal(t, "ps10002", d.Get("name"))
+
+	// servers removed outside of Terraform are dropped from state
+	missing := schema.TestResourceDataRaw(t, resourcePrivateServer().Schema, map[string]interface{}{})
+	missing.SetId("ps99999")
+	diags = resourcePrivateServerRead(context.Background(), missing, client)
+	require.False(t, diags.HasError(), "%v", diags)
+	assert.Empty(t, missing.Id())
+}
+
+func TestParsePrivateServerSettings(t *testing.T) {
+	t.Parallel()
+
+	settings, err := parsePrivateServerSettings([]byte(testPrivateServerSettingsResponse))
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{
+		"apache2_enabled": "0", "nginx_enabled": "1", "monitoring": "1", "admin_user": "1",
+	}, settings)
+
+	settings, err = parsePrivateServerSettings([]byte(`[]`))
+	require.NoError(t, err)
+	assert.Empty(t, settings)
+
+	// shapes other than the documented rows are reported instead of read as no settings
+	for _, data := range []string{
+		`"no_settings"`,
+		`{"apache2_enabled":"1","monitoring":"0"}`,
+		`[{"name":"apache2_enabled","value":"1"}]`,
+		`[{"setting":"monitoring","value":0}]`,
+	} {
+		_, err = parsePrivateServerSettings([]byte(data))
+		assert.Error(t, err, data)
+	}
+}
+
+func TestResourcePrivateServerSettings(t *testing.T) {
+	t.Parallel()
+
+	caller := newFakeCommandCaller()
+	caller.Respond(listPrivateServersCmd, testPrivateServersResponse)
+	caller.Respond(listPrivateServerSettingsCmd+"?ps=ps10001", testPrivateServerSettingsResponse)
+	caller.Respond(setPrivateServerSettingsCmd, `"success"`)
+	client := newFakeCommandClient(caller)
+
+	// drift is only reported for owned keys
+	d := schema.TestResourceDataRaw(t, resourcePrivateServerSettings().Schema, map[string]interface{}{
+		"ps":       "ps10001",
+		"settings": map[string]interface{}{"apache2_enabled": "1", "monitoring": "1"},
+	})
+	d.SetId("ps10001")
+	diags := resourcePrivateServerSettingsRead(context.Background(), d, client)
+	require.False(t, diags.HasError(), "%v", diags)
+	assert.Equal(t, map[string]interface{}{"apache2_enabled": "0", "monitoring": "1"}, d.Get("settings"))
+
+	// an import takes ownership of all current settings
+	imported := schema.TestResourceDataRaw(t, resourcePrivateServerSettings().Schema, map[string]interface{}{})
+	imported.SetId("ps10001")
+	diags = resourcePrivateServerSettingsRead(context.Background(), imported, client)
+	require.False(t, diags.HasError(), "%v", diags)
+	assert.Equal(t, "ps10001", imported.Get("ps"))
+	assert.Len(t, imported.Get("settings"), 4)
+
+	// only the configured settings are sent
+	created := schema.TestResourceDataRaw(t, resourcePrivateServerSettings().Schema, map[string]interface{}{
+		"ps":       "ps10001",
+		"settings": map[string]interface{}{"apache2_enabled": "1"},
+	})
+	diags = resourcePrivateServerSettingsCreate(context.Background(), created, client)
+	require.False(t, diags.HasError(), "%v", diags)
+	assert.Contains(t, caller.Calls(), setPrivateServerSettingsCmd+"?apache2_enabled=1&ps=ps10001")
+	assert.Equal(t, "ps10001", created.Id())
+
+	missing := schema.TestResourceDataRaw(t, resourcePrivateServerSettings().Schema, map[string]interface{}{})
+	missing.SetId("ps99999")
+	diags = resourcePrivateServerSettingsRead(context.Background(), missing, client)
+	require.False(t, diags.HasError(), "%v", diags)
+	assert.Empty(t, missing.Id())
+}
@@ -25,11 +25,38 @@ func Provider() *schema.Provider {
 			},
 		},
 		ResourcesMap: map[string]*schema.Resource{
-			"dreamhost_dns_record": resourceDNSRecord(),
+			"dreamhost_dns_record":                   resourceDNSRecord(),
+			"dreamhost_dns_spf_record":               resourceDNSSPFRecord(),
+			"dreamhost_dns_dmarc_policy":             resourceDNSDMARCPolicy(),
+			"dreamhost_dns_dkim_key":                 resourceDNSDKIMKey(),
+			"dreamhost_acme_dns01_challenge":         resourceACMEDNS01Challenge(),
+			"dreamhost_dns_naptr_record":             resourceDNSNAPTRRecord(),
+			"dreamhost_dns_ptr_record":               resourceDNSPTRRecord(),
+			"dreamhost_mail_filter":                  resourceMailFilter(),
+			"dreamhost_announcement_list_subscriber": resourceAnnouncementListSubscriber(),
+			"dreamhost_mysql_hostname":               resourceMySQLHostname(),
+			"dreamhost_mysql_user":                   resourceMySQLUser(),
+			"dreamhost_user":                         resourceUser(),
+			"dreamhost_private_server":               resourcePrivateServer(),
+			"dreamhost_private_server_settings":      resourcePrivateServerSettings(),
 		},
 		DataSourcesMap: map[string]*schema.Resource{
-			"dreamhost_dns_record":  dataSourceDNSRecord(),
-			"dreamhost_dns_records": dataSourceDNSRecords(),
+			"dreamhost_dns_record":             dataSourceDNSRecord(),
+			"dreamhost_dns_records":            dataSourceDNSRecords(),
+			"dreamhost_dns_zones":              dataSourceDNSZones(),
+			"dreamhost_dns_zone_export":        dataSourceDNSZoneExport(),
+			"dreamhost_dns_resolution":         dataSourceDNSResolution(),
+			"dreamhost_domains":                dataSourceDomains(),
+			"dreamhost_domain":                 dataSourceDomain(),
+			"dreamhost_domain_registrations":   dataSourceDomainRegistrations(),
+			"dreamhost_domain_availability":    dataSourceDomainAvailability(),
+			"dreamhost_announcement_lists":     dataSourceAnnouncementLists(),
+			"dreamhost_mysql_databases":        dataSourceMySQLDatabases(),
+			"dreamhost_mysql_users":            dataSourceMySQLUsers(),
+			"dreamhost_users":                  dataSourceUsers(),
+			"dreamhost_private_server_usage":   dataSourcePrivateServerUsage(),
+			"dreamhost_private_server_history": dataSourcePrivateServerHistory(),
+			"dreamhost_private_server_images":  dataSourcePrivateServerImages(),
 		},
 		ConfigureContextFunc: providerConfigure,
 	}
@@ -66,6 +93,8 @@ func providerConfigure(ctx context.Context, d *schema.ResourceData) (interface{}
 	}
 
 	cachedAPI := newDreamhostClient(api)
+	cachedAPI.commands = newCommandClient(apiKey, nil)
+	cachedAPI.limiter = newRateLimiter(apiCallInterval)
 
 	return cachedAPI, diags
 }
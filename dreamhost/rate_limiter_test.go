@@ -0,0 +1,31 @@
+package dreamhost
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRateLimiterWait(t *testing.T) {
+	t.Parallel()
+
+	limiter := newRateLimiter(50 * time.Millisecond)
+	start := time.Now()
+	for i := 0; i < 3; i++ {
+		require.NoError(t, limiter.Wait(context.Background()))
+	}
+	assert.GreaterOrEqual(t, time.Since(start), 100*time.Millisecond)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	slow := newRateLimiter(time.Hour)
+	require.NoError(t, slow.Wait(ctx))
+	assert.ErrorIs(t, slow.Wait(ctx), context.Canceled)
+
+	// clients built without a limiter are not throttled
+	var unlimited *rateLimiter
+	assert.NoError(t, unlimited.Wait(context.Background()))
+}
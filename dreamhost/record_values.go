@@ -0,0 +1,89 @@
+package dreamhost
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+const (
+	mxFields  = 2
+	srvFields = 4
+)
+
+// mxValue is the structured form of an MX record value
+type mxValue struct {
+	Priority int
+	Exchange string
+}
+
+// srvValue is the structured form of an SRV record value
+type srvValue struct {
+	Priority int
+	Weight   int
+	Port     int
+	Target   string
+}
+
+// parseMXValue parses a "priority exchange" MX value
+func parseMXValue(value string) (*mxValue, error) {
+	fields := strings.Fields(value)
+	if len(fields) != mxFields {
+		return nil, fmt.Errorf("MX record must be in format 'priority hostname', got: %s", value)
+	}
+	priority, err := strconv.ParseUint(fields[0], 10, 16)
+	if err != nil {
+		return nil, fmt.Errorf("MX priority must be a number between 0 and 65535, got: %s", fields[0])
+	}
+	return &mxValue{Priority: int(priority), Exchange: strings.TrimSuffix(fields[1], ".")}, nil
+}
+
+// parseSRVValue parses a "priority weight port target" SRV value
+func parseSRVValue(value string) (*srvValue, error) {
+	fields := strings.Fields(value)
+	if len(fields) != srvFields {
+		return nil, fmt.Errorf("SRV record must be in format 'priority weight port target', got: %s", value)
+	}
+
+	numbers := make([]int, srvFields-1)
+	for i, name := range []string{"priority", "weight", "port"} {
+		number, err := strconv.ParseUint(fields[i], 10, 16)
+		if err != nil {
+			return nil, fmt.Errorf("SRV %s must be a number between 0 and 65535, got: %s", name, fields[i])
+		}
+		numbers[i] = int(number)
+	}
+
+	return &srvValue{
+		Priority: numbers[0],
+		Weight:   numbers[1],
+		Port:     numbers[2],
+		Target:   strings.TrimSuffix(fields[3], "."),
+	}, nil
+}
+
+// sortRecordValues sorts MX and SRV values by priority and all other values
+// lexically, so the first value is the preferred one
+func sortRecordValues(recordType string, values []string) {
+	priority := func(value string) int {
+		switch recordType {
+		case "MX":
+			if mx, err := parseMXValue(value); err == nil {
+				return mx.Priority
+			}
+		case "SRV":
+			if srv, err := parseSRVValue(value); err == nil {
+				return srv.Priority
+			}
+		}
+		return 0
+	}
+
+	sort.SliceStable(values, func(i, j int) bool {
+		if pi, pj := priority(values[i]), priority(values[j]); pi != pj {
+			return pi < pj
+		}
+		return values[i] < values[j]
+	})
+}
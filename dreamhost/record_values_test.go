@@ -0,0 +1,68 @@
+package dreamhost
+
+import (
+	"testing"
+
+	dreamhostapi "github.com/adamantal/go-dreamhost/api"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseMXValue(t *testing.T) {
+	t.Parallel()
+
+	mx, err := parseMXValue("10 mail.example.com.")
+	require.NoError(t, err)
+	assert.Equal(t, mxValue{Priority: 10, Exchange: "mail.example.com"}, *mx)
+
+	_, err = parseMXValue("mail.example.com")
+	assert.Error(t, err)
+	_, err = parseMXValue("70000 mail.example.com")
+	assert.Error(t, err)
+}
+
+func TestParseSRVValue(t *testing.T) {
+	t.Parallel()
+
+	srv, err := parseSRVValue("10 60 5060 sip.example.com")
+	require.NoError(t, err)
+	assert.Equal(t, srvValue{Priority: 10, Weight: 60, Port: 5060, Target: "sip.example.com"}, *srv)
+
+	_, err = parseSRVValue("10 60 sip.example.com")
+	assert.Error(t, err)
+	_, err = parseSRVValue("10 heavy 5060 sip.example.com")
+	assert.ErrorContains(t, err, "SRV weight")
+}
+
+func TestSortRecordValues(t *testing.T) {
+	t.Parallel()
+
+	mx := []string{"20 b.example.com", "5 c.example.com", "10 a.example.com"}
+	sortRecordValues("MX", mx)
+	assert.Equal(t, []string{"5 c.example.com", "10 a.example.com", "20 b.example.com"}, mx)
+
+	a := []string{"192.0.2.2", "192.0.2.10", "192.0.2.1"}
+	sortRecordValues("A", a)
+	assert.Equal(t, []string{"192.0.2.1", "192.0.2.10", "192.0.2.2"}, a)
+}
+
+func TestParsedRecordFields(t *testing.T) {
+	t.Parallel()
+
+	fields := parsedRecordFields(dreamhostapi.DNSRecord{
+		Record: "_sip._tcp.Example.com", Zone: "example.com", Type: dreamhostapi.SRVRecordType, Value: "0 5 5060 sip.example.com.",
+	})
+	assert.Equal(t, "_sip._tcp.example.com", fields["fqdn"])
+	assert.Equal(t, "_sip._tcp", fields["relative_name"])
+	assert.Equal(t, 5060, fields["srv_port"])
+	assert.Equal(t, "sip.example.com", fields["srv_target"])
+	assert.Equal(t, 0, fields["mx_priority"])
+
+	fields = parsedRecordFields(dreamhostapi.DNSRecord{
+		Record: "example.com", Zone: "example.com", Type: "MX", Value: "10 mail.example.com",
+	})
+	assert.Equal(t, "@", fields["relative_name"])
+	assert.Equal(t, 10, fields["mx_priority"])
+	assert.Equal(t, "mail.example.com", fields["mx_exchange"])
+	assert.Equal(t, "", fields["srv_target"])
+}
@@ -0,0 +1,157 @@
+package dreamhost
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	dreamhostapi "github.com/adamantal/go-dreamhost/api"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/pkg/errors"
+)
+
+const (
+	acmeChallengeLabel = "_acme-challenge"
+
+	defaultACMEPropagationTimeout = 10 * time.Minute
+)
+
+// dreamhostNameservers are the authoritative nameservers of zones hosted at DreamHost
+var dreamhostNameservers = []string{"ns1.dreamhost.com", "ns2.dreamhost.com", "ns3.dreamhost.com"} // nolint:gochecknoglobals
+
+func resourceACMEDNS01Challenge() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceACMEDNS01ChallengeCreate,
+		ReadContext:   resourceACMEDNS01ChallengeRead,
+		UpdateContext: nil,
+		DeleteContext: resourceDNSRecordDelete,
+		Schema: mergeSchemas(map[string]*schema.Schema{
+			"domain": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "the domain being validated; a leading `*.` of wildcard identifiers is ignored",
+			},
+			"token": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "the key authorization digest the ACME server expects in the TXT record",
+			},
+			"nameservers": {
+				Type:     schema.TypeList,
+				Optional: true,
+				ForceNew: true,
+				Description: "nameservers (host or host:port) that must serve the token before creation completes, " +
+					"defaults to the DreamHost nameservers",
+				Elem: &schema.Schema{Type: schema.TypeString},
+			},
+
+			// computed values
+			"record": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "the name of the challenge TXT record",
+			},
+		}, dnsRecordComputedSchema()),
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(defaultACMEPropagationTimeout),
+		},
+	}
+}
+
+func resourceACMEDNS01ChallengeCreate(ctx context.Context, data *schema.ResourceData, config interface{}) diag.Diagnostics {
+	api, ok := config.(*cachedDreamhostClient) // nolint:varnamelen
+	if !ok {
+		return diag.Errorf("internal error: failed to retrieve dreamhost API client")
+	}
+
+	domain, _ := data.Get("domain").(string)
+	token, _ := data.Get("token").(string)
+	nameservers := expandStringList(data.Get("nameservers").([]interface{}))
+	if len(nameservers) == 0 {
+		nameservers = dreamhostNameservers
+	}
+
+	recordInput := dreamhostapi.DNSRecordInput{
+		Record: acmeChallengeRecordName(domain),
+		Value:  token,
+		Type:   dreamhostapi.TXTRecordType,
+	}
+
+	// Add record with retry
+	err := retryOnError(ctx, func() error {
+		return api.AddDNSRecord(ctx, recordInput)
+	})
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	data.SetId(recordInputToID(recordInput))
+
+	// Wait for record to be available in the API, then on the nameservers
+	dnsRecord, err := waitForDNSRecord(ctx, api, recordInput)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if err := refreshDataFromACMEChallengeRecord(data, *dnsRecord); err != nil {
+		return diag.FromErr(err)
+	}
+
+	err = waitForTXTPropagation(ctx, nameservers, recordInput.Record, token, data.Timeout(schema.TimeoutCreate))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+func resourceACMEDNS01ChallengeRead(ctx context.Context, data *schema.ResourceData, config interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	api, ok := config.(*cachedDreamhostClient)
+	if !ok {
+		return diag.Errorf("internal error: failed to retrieve dreamhost API client")
+	}
+
+	recordInput, err := idToRecordInput(data.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	record, err := api.GetDNSRecord(ctx, *recordInput, true)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	// record is completely missing
+	if record == nil {
+		if data.IsNewResource() {
+			return diag.Errorf("ACME challenge record not found: %s", data.Id())
+		}
+		data.SetId("")
+		return diags
+	}
+
+	if err := refreshDataFromACMEChallengeRecord(data, *record); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return diags
+}
+
+func refreshDataFromACMEChallengeRecord(data *schema.ResourceData, record dreamhostapi.DNSRecord) error {
+	if err := data.Set("record", record.Record); err != nil {
+		return errors.Wrap(err, "failed to set field `record`")
+	}
+	if err := data.Set("token", record.Value); err != nil {
+		return errors.Wrap(err, "failed to set field `token`")
+	}
+	return refreshComputedFromRecord(data, record)
+}
+
+// acmeChallengeRecordName returns the name of the DNS-01 challenge record of a domain
+func acmeChallengeRecordName(domain string) string {
+	return acmeChallengeLabel + "." + strings.TrimSuffix(strings.TrimPrefix(domain, "*."), ".")
+}
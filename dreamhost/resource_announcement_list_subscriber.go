@@ -0,0 +1,185 @@
+package dreamhost
+
+import (
+	"context"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/pkg/errors"
+)
+
+func resourceAnnouncementListSubscriber() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceAnnouncementListSubscriberCreate,
+		ReadContext:   resourceAnnouncementListSubscriberRead,
+		UpdateContext: nil,
+		DeleteContext: resourceAnnouncementListSubscriberDelete,
+		Schema: map[string]*schema.Schema{
+			"listname": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "the list name, the local part of the list address",
+			},
+			"domain": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "the domain of the list",
+			},
+			"email": {
+				Type:             schema.TypeString,
+				Required:         true,
+				ForceNew:         true,
+				ValidateFunc:     ValidateEmailAddress(),
+				DiffSuppressFunc: suppressEmailCaseDiff,
+				Description:      "the address of the subscriber, compared case-insensitively",
+			},
+			"name": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				ForceNew:    true,
+				Description: "the name of the subscriber",
+			},
+
+			// computed values
+			"confirmed": {
+				Type:        schema.TypeBool,
+				Computed:    true,
+				Description: "whether the subscriber confirmed the subscription",
+			},
+			"subscribe_date": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "the date the address was subscribed",
+			},
+			"bounce_count": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "the number of bounced messages",
+			},
+		},
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+	}
+}
+
+// suppressEmailCaseDiff ignores case differences between the configured address
+// and the one the API returns; subscribers are looked up case-insensitively too
+func suppressEmailCaseDiff(_, old, new string, _ *schema.ResourceData) bool {
+	return strings.EqualFold(old, new)
+}
+
+func resourceAnnouncementListSubscriberCreate(
+	ctx context.Context, data *schema.ResourceData, config interface{},
+) diag.Diagnostics {
+	api, ok := config.(*cachedDreamhostClient) // nolint:varnamelen
+	if !ok {
+		return diag.Errorf("internal error: failed to retrieve dreamhost API client")
+	}
+
+	listName, _ := data.Get("listname").(string)
+	domain, _ := data.Get("domain").(string)
+	email, _ := data.Get("email").(string)
+	name, _ := data.Get("name").(string)
+
+	existing, err := findSubscriber(ctx, api, listName, domain, email)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if existing != nil {
+		return diag.Errorf("subscriber already exists, import it with ID %q", subscriberToID(listName, domain, email))
+	}
+
+	params := announcementListParams(listName, domain)
+	params.Set("email", email)
+	if name != "" {
+		params.Set("name", name)
+	}
+	if err := api.CallCommand(ctx, addSubscriberCmd, params, nil); err != nil {
+		return diag.FromErr(err)
+	}
+
+	data.SetId(subscriberToID(listName, domain, email))
+
+	return resourceAnnouncementListSubscriberRead(ctx, data, config)
+}
+
+func resourceAnnouncementListSubscriberRead(
+	ctx context.Context, data *schema.ResourceData, config interface{},
+) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	api, ok := config.(*cachedDreamhostClient)
+	if !ok {
+		return diag.Errorf("internal error: failed to retrieve dreamhost API client")
+	}
+
+	listName, domain, email, err := idToSubscriber(data.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	subscriber, err := findSubscriber(ctx, api, listName, domain, email)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	// subscriber is completely missing
+	if subscriber == nil {
+		if data.IsNewResource() {
+			return diag.Errorf("subscriber not found: %s", data.Id())
+		}
+		data.SetId("")
+		return diags
+	}
+
+	bounces, _ := strconv.Atoi(subscriber.NumBounces)
+
+	fields := map[string]interface{}{
+		"listname":       listName,
+		"domain":         domain,
+		"email":          subscriber.Email,
+		"name":           subscriber.Name,
+		"confirmed":      isAPIFlagSet(subscriber.Confirmed),
+		"subscribe_date": subscriber.SubscribeDate,
+		"bounce_count":   bounces,
+	}
+	for key, value := range fields {
+		if err := data.Set(key, value); err != nil {
+			return diag.FromErr(errors.Wrapf(err, "failed to set field `%s`", key))
+		}
+	}
+
+	return diags
+}
+
+func resourceAnnouncementListSubscriberDelete(
+	ctx context.Context, data *schema.ResourceData, config interface{},
+) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	api, ok := config.(*cachedDreamhostClient)
+	if !ok {
+		return diag.Errorf("internal error: failed to retrieve dreamhost API client")
+	}
+
+	listName, domain, email, err := idToSubscriber(data.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	params := announcementListParams(listName, domain)
+	params.Set("email", email)
+	// a subscriber that is already gone is as good as removed
+	if err := api.CallCommand(ctx, removeSubscriberCmd, params, nil); err != nil &&
+		!isCommandError(err, subscriberNotFoundCode) {
+		return diag.FromErr(err)
+	}
+
+	return diags
+}
@@ -0,0 +1,190 @@
+package dreamhost
+
+import (
+	"context"
+
+	dreamhostapi "github.com/adamantal/go-dreamhost/api"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/pkg/errors"
+)
+
+const (
+	defaultDKIMRSABits = 2048
+)
+
+func resourceDNSDKIMKey() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceDNSDKIMKeyCreate,
+		ReadContext:   resourceDNSDKIMKeyRead,
+		UpdateContext: nil,
+		DeleteContext: resourceDNSRecordDelete,
+		Schema: mergeSchemas(map[string]*schema.Schema{
+			"domain": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: ValidateDomainName(),
+				Description:  "the domain the key signs mail for",
+			},
+			"selector": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: ValidateDomainName(),
+				Description:  "the DKIM selector; changing it generates and publishes a new key",
+			},
+			"algorithm": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ForceNew:     true,
+				Default:      dkimAlgorithmRSA,
+				ValidateFunc: validation.StringInSlice([]string{dkimAlgorithmRSA, dkimAlgorithmEd25519}, false),
+				Description:  "the key algorithm (rsa or ed25519)",
+			},
+			"rsa_bits": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				ForceNew:     true,
+				Default:      defaultDKIMRSABits,
+				ValidateFunc: validation.IntInSlice([]int{1024, 2048, 3072, 4096}),
+				Description:  "the size of generated RSA keys, ignored for ed25519",
+			},
+
+			// computed values
+			"record": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "the name of the TXT record the key is published at",
+			},
+			"value": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "the published TXT record value",
+			},
+			"public_key": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "the base64 encoded public key, as published in the p= tag",
+			},
+			"private_key_pem": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Sensitive:   true,
+				Description: "the PKCS#8 PEM encoded private key to configure the signing mail server with",
+			},
+		}, dnsRecordComputedSchema()),
+	}
+}
+
+func resourceDNSDKIMKeyCreate(ctx context.Context, data *schema.ResourceData, config interface{}) diag.Diagnostics {
+	api, ok := config.(*cachedDreamhostClient) // nolint:varnamelen
+	if !ok {
+		return diag.Errorf("internal error: failed to retrieve dreamhost API client")
+	}
+
+	domain, _ := data.Get("domain").(string)
+	selector, _ := data.Get("selector").(string)
+	algorithm, _ := data.Get("algorithm").(string)
+	rsaBits, _ := data.Get("rsa_bits").(int)
+
+	key, err := generateDKIMKey(algorithm, rsaBits)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	// The value of an RSA key is longer than a single TXT string; it is passed to
+	// the API as is. Unlike SPF records it is not warned about: the value is always
+	// the one generated here, so the warning could not be acted on.
+	recordInput := dreamhostapi.DNSRecordInput{
+		Record: dkimRecordName(selector, domain),
+		Value:  key.String(),
+		Type:   dreamhostapi.TXTRecordType,
+	}
+
+	// Add record with retry
+	err = retryOnError(ctx, func() error {
+		return api.AddDNSRecord(ctx, recordInput)
+	})
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	data.SetId(recordInputToID(recordInput))
+	if err := data.Set("private_key_pem", key.PrivateKeyPEM); err != nil {
+		return diag.Errorf("failed to set field `private_key_pem`: %v", err)
+	}
+
+	// Wait for record to be available
+	dnsRecord, err := waitForDNSRecord(ctx, api, recordInput)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if err := refreshDataFromDKIMRecord(data, *dnsRecord); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+func resourceDNSDKIMKeyRead(ctx context.Context, data *schema.ResourceData, config interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	api, ok := config.(*cachedDreamhostClient)
+	if !ok {
+		return diag.Errorf("internal error: failed to retrieve dreamhost API client")
+	}
+
+	recordInput, err := idToRecordInput(data.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	record, err := api.GetDNSRecord(ctx, *recordInput, true)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	// record is completely missing, the private key is of no use without it
+	if record == nil {
+		if data.IsNewResource() {
+			return diag.Errorf("DKIM record not found: %s", data.Id())
+		}
+		data.SetId("")
+		return diags
+	}
+
+	if err := refreshDataFromDKIMRecord(data, *record); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return diags
+}
+
+func refreshDataFromDKIMRecord(data *schema.ResourceData, record dreamhostapi.DNSRecord) error {
+	key, err := parseDKIMRecord(record.Value)
+	if err != nil {
+		return err
+	}
+	selector, domain, err := splitDKIMRecordName(record.Record)
+	if err != nil {
+		return err
+	}
+
+	fields := map[string]interface{}{
+		"domain":     domain,
+		"selector":   selector,
+		"algorithm":  key.Algorithm,
+		"record":     record.Record,
+		"value":      record.Value,
+		"public_key": key.PublicKey,
+	}
+	for field, value := range fields {
+		if err := data.Set(field, value); err != nil {
+			return errors.Wrapf(err, "failed to set field `%s`", field)
+		}
+	}
+
+	return refreshComputedFromRecord(data, record)
+}
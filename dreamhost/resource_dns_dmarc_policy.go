@@ -0,0 +1,307 @@
+package dreamhost
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+
+	dreamhostapi "github.com/adamantal/go-dreamhost/api"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/pkg/errors"
+)
+
+func resourceDNSDMARCPolicy() *schema.Resource {
+	policies := []string{"none", "quarantine", "reject"}
+	alignments := []string{"r", "s"}
+	reportURI := &schema.Schema{
+		Type: schema.TypeString,
+		ValidateFunc: validation.StringMatch(
+			regexp.MustCompile(`^(mailto:[^\s,;!@]+@[^\s,;!@]+|https?://[^\s,;!]+)(![0-9]+[kmgt]?)?$`),
+			"must be a mailto: or http(s): URI, optionally followed by a size limit such as !10m",
+		),
+	}
+
+	return &schema.Resource{
+		CreateContext: resourceDNSDMARCPolicyCreate,
+		ReadContext:   resourceDNSDMARCPolicyRead,
+		UpdateContext: nil,
+		DeleteContext: resourceDNSRecordDelete,
+		Schema: mergeSchemas(map[string]*schema.Schema{
+			"domain": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: ValidateDomainName(),
+				Description:  "the domain the DMARC policy applies to",
+			},
+			"policy": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.StringInSlice(policies, false),
+				Description:  "the policy for mail failing DMARC (none, quarantine or reject)",
+			},
+			"subdomain_policy": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.StringInSlice(policies, false),
+				Description:  "the policy for subdomains, defaults to `policy` when unset",
+			},
+			"pct": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				ForceNew:     true,
+				Default:      dmarcDefaultPct,
+				ValidateFunc: validation.IntBetween(0, dmarcDefaultPct),
+				Description:  "the percentage of failing mail the policy is applied to",
+			},
+			"rua": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				ForceNew:    true,
+				Elem:        reportURI,
+				Description: "URIs aggregate reports are sent to",
+			},
+			"ruf": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				ForceNew:    true,
+				Elem:        reportURI,
+				Description: "URIs failure reports are sent to",
+			},
+			"adkim": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ForceNew:     true,
+				Default:      dmarcDefaultAlignment,
+				ValidateFunc: validation.StringInSlice(alignments, false),
+				Description:  "the DKIM identifier alignment mode (r for relaxed, s for strict)",
+			},
+			"aspf": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ForceNew:     true,
+				Default:      dmarcDefaultAlignment,
+				ValidateFunc: validation.StringInSlice(alignments, false),
+				Description:  "the SPF identifier alignment mode (r for relaxed, s for strict)",
+			},
+			"fo": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+				Default:  dmarcDefaultFailure,
+				ValidateFunc: validation.StringMatch(
+					regexp.MustCompile(`^[01ds](:[01ds])*$`),
+					"must be a colon-separated list of 0, 1, d and s",
+				),
+				Description: "the failure reporting options (colon-separated list of 0, 1, d and s)",
+			},
+			"extra_tags": {
+				Type:         schema.TypeMap,
+				Optional:     true,
+				ForceNew:     true,
+				Elem:         &schema.Schema{Type: schema.TypeString},
+				ValidateFunc: validateDMARCExtraTags,
+				Description:  "further tags to publish, such as rf or ri, rendered in key order after the others",
+			},
+
+			// computed values
+			"record": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "the name of the TXT record the policy is published at",
+			},
+			"value": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "the rendered TXT record value",
+			},
+		}, dnsRecordComputedSchema()),
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceDNSDMARCPolicyImport,
+		},
+	}
+}
+
+func resourceDNSDMARCPolicyCreate(ctx context.Context, data *schema.ResourceData, config interface{}) diag.Diagnostics {
+	api, ok := config.(*cachedDreamhostClient) // nolint:varnamelen
+	if !ok {
+		return diag.Errorf("internal error: failed to retrieve dreamhost API client")
+	}
+
+	domain, ok := data.Get("domain").(string)
+	if !ok {
+		return diag.Errorf("internal error: failed to retrieve domain property for DMARC policy creation")
+	}
+
+	records, err := api.ListCachedDNSRecords(ctx)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if existing := findDMARCRecords(records, domain, ""); len(existing) > 0 {
+		return diag.Errorf(
+			"a DMARC record already exists for %s (%q); import it or remove it first", domain, existing[0].Value,
+		)
+	}
+
+	recordInput := dreamhostapi.DNSRecordInput{
+		Record: dmarcRecordName(domain),
+		Value:  dmarcPolicyFromConfig(data).String(),
+		Type:   dreamhostapi.TXTRecordType,
+	}
+
+	// Add record with retry
+	err = retryOnError(ctx, func() error {
+		return api.AddDNSRecord(ctx, recordInput)
+	})
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	data.SetId(recordInputToID(recordInput))
+
+	// Wait for record to be available
+	dnsRecord, err := waitForDNSRecord(ctx, api, recordInput)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if err := refreshDataFromDMARCRecord(data, *dnsRecord); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+func resourceDNSDMARCPolicyRead(ctx context.Context, data *schema.ResourceData, config interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	api, ok := config.(*cachedDreamhostClient)
+	if !ok {
+		return diag.Errorf("internal error: failed to retrieve dreamhost API client")
+	}
+
+	recordInput, err := idToRecordInput(data.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	record, err := api.GetDNSRecord(ctx, *recordInput, true)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	// record is completely missing
+	if record == nil {
+		if data.IsNewResource() {
+			return diag.Errorf("DMARC record not found: %s", data.Id())
+		}
+		data.SetId("")
+		return diags
+	}
+
+	if err := refreshDataFromDMARCRecord(data, *record); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return diags
+}
+
+// resourceDNSDMARCPolicyImport accepts either a DNS record ID or the bare domain
+// name, in which case the single DMARC record published for it is looked up
+func resourceDNSDMARCPolicyImport(
+	ctx context.Context, data *schema.ResourceData, config interface{},
+) ([]*schema.ResourceData, error) {
+	if strings.Contains(data.Id(), "|") {
+		return []*schema.ResourceData{data}, nil
+	}
+
+	api, ok := config.(*cachedDreamhostClient)
+	if !ok {
+		return nil, errors.New("internal error: failed to retrieve dreamhost API client")
+	}
+
+	records, err := api.ListCachedDNSRecords(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	found := findDMARCRecords(records, data.Id(), "")
+	switch len(found) {
+	case 0:
+		return nil, errors.Errorf("no DMARC record found for %s", data.Id())
+	case 1:
+	default:
+		return nil, errors.Errorf("multiple DMARC records found for %s, import one by its record ID", data.Id())
+	}
+
+	data.SetId(recordInputToID(dreamhostapi.DNSRecordInput{
+		Record: found[0].Record,
+		Type:   found[0].Type,
+		Value:  found[0].Value,
+	}))
+
+	return []*schema.ResourceData{data}, nil
+}
+
+func refreshDataFromDMARCRecord(data *schema.ResourceData, record dreamhostapi.DNSRecord) error {
+	policy, err := parseDMARCPolicy(record.Value)
+	if err != nil {
+		return err
+	}
+	if !strings.HasPrefix(strings.ToLower(record.Record), dmarcRecordLabel+".") {
+		return errors.Errorf("DMARC record must be published at %s.<domain>, got %s", dmarcRecordLabel, record.Record)
+	}
+
+	fields := map[string]interface{}{
+		"domain":           record.Record[len(dmarcRecordLabel)+1:],
+		"policy":           policy.Policy,
+		"subdomain_policy": policy.SubdomainPolicy,
+		"pct":              policy.Pct,
+		"rua":              policy.RUA,
+		"ruf":              policy.RUF,
+		"adkim":            policy.ADKIM,
+		"aspf":             policy.ASPF,
+		"fo":               policy.FO,
+		"extra_tags":       policy.Extra,
+		"record":           record.Record,
+		"value":            record.Value,
+	}
+	for key, value := range fields {
+		if err := data.Set(key, value); err != nil {
+			return errors.Wrapf(err, "failed to set field `%s`", key)
+		}
+	}
+
+	return refreshComputedFromRecord(data, record)
+}
+
+// dmarcPolicyFromConfig builds the structured DMARC policy from resource data
+func dmarcPolicyFromConfig(data *schema.ResourceData) dmarcPolicy {
+	policy := dmarcPolicy{}
+	policy.Policy, _ = data.Get("policy").(string)
+	policy.SubdomainPolicy, _ = data.Get("subdomain_policy").(string)
+	policy.Pct, _ = data.Get("pct").(int)
+	policy.RUA = expandStringList(data.Get("rua").([]interface{}))
+	policy.RUF = expandStringList(data.Get("ruf").([]interface{}))
+	policy.ADKIM, _ = data.Get("adkim").(string)
+	policy.ASPF, _ = data.Get("aspf").(string)
+	policy.FO, _ = data.Get("fo").(string)
+	if extra := expandSettings(data.Get("extra_tags")); len(extra) > 0 {
+		policy.Extra = extra
+	}
+	return policy
+}
+
+// validateDMARCExtraTags rejects tags that have an attribute of their own
+func validateDMARCExtraTags(i interface{}, k string) (warnings []string, errors []error) {
+	for key := range expandSettings(i) {
+		if containsString(dmarcModeledTags, strings.ToLower(key)) || !dmarcTagStart.MatchString(key+"=") {
+			errors = append(errors, fmt.Errorf("%s: %q is not a DMARC tag without an attribute of its own", k, key))
+		}
+	}
+	return warnings, errors
+}
@@ -0,0 +1,202 @@
+package dreamhost
+
+import (
+	"context"
+	"regexp"
+
+	dreamhostapi "github.com/adamantal/go-dreamhost/api"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/pkg/errors"
+)
+
+func resourceDNSNAPTRRecord() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceDNSNAPTRRecordCreate,
+		ReadContext:   resourceDNSNAPTRRecordRead,
+		UpdateContext: nil,
+		DeleteContext: resourceDNSRecordDelete,
+		CustomizeDiff: resourceDNSNAPTRRecordCustomizeDiff,
+		Schema: mergeSchemas(map[string]*schema.Schema{
+			"record": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "the name of the DNS record",
+			},
+			"order": {
+				Type:         schema.TypeInt,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.IntBetween(0, 65535),
+				Description:  "the order in which NAPTR records must be processed, lowest first",
+			},
+			"preference": {
+				Type:         schema.TypeInt,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.IntBetween(0, 65535),
+				Description:  "the preference among records with equal order, lowest first",
+			},
+			"flags": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.StringMatch(regexp.MustCompile(`^[a-zA-Z0-9]*$`), "must only contain letters and digits"),
+				Description:  "the flags controlling the rewriting (e.g. S, A, U or P)",
+			},
+			"service": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				ForceNew:    true,
+				Description: "the service parameters (e.g. SIP+D2U or E2U+sip)",
+			},
+			"regexp": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				ForceNew:    true,
+				Description: "the substitution expression applied to the original string",
+			},
+			"replacement": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ForceNew:     true,
+				Default:      ".",
+				ValidateFunc: validation.Any(validation.StringInSlice([]string{"."}, false), ValidateDomainName()),
+				Description:  "the next domain name to query, `.` when `regexp` is used",
+			},
+
+			// computed values
+			"value": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "the rendered NAPTR record value",
+			},
+		}, dnsRecordComputedSchema()),
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+	}
+}
+
+func resourceDNSNAPTRRecordCustomizeDiff(_ context.Context, diff *schema.ResourceDiff, _ interface{}) error {
+	pattern, _ := diff.Get("regexp").(string)
+	replacement, _ := diff.Get("replacement").(string)
+	if pattern != "" && replacement != "." {
+		return errors.New("`regexp` and `replacement` are mutually exclusive, set `replacement` to \".\" to use a regexp")
+	}
+	return nil
+}
+
+func resourceDNSNAPTRRecordCreate(ctx context.Context, data *schema.ResourceData, config interface{}) diag.Diagnostics {
+	api, ok := config.(*cachedDreamhostClient) // nolint:varnamelen
+	if !ok {
+		return diag.Errorf("internal error: failed to retrieve dreamhost API client")
+	}
+
+	record, ok := data.Get("record").(string)
+	if !ok {
+		return diag.Errorf("internal error: failed to retrieve record property for NAPTR record creation")
+	}
+
+	naptr := naptrRecord{}
+	naptr.Order, _ = data.Get("order").(int)
+	naptr.Preference, _ = data.Get("preference").(int)
+	naptr.Flags, _ = data.Get("flags").(string)
+	naptr.Service, _ = data.Get("service").(string)
+	naptr.Regexp, _ = data.Get("regexp").(string)
+	naptr.Replacement, _ = data.Get("replacement").(string)
+	if err := naptr.validate(); err != nil {
+		return diag.FromErr(err)
+	}
+
+	recordInput := dreamhostapi.DNSRecordInput{
+		Record: record,
+		Value:  naptr.String(),
+		Type:   dreamhostapi.NAPTRRecordType,
+	}
+
+	// Add record with retry
+	err := retryOnError(ctx, func() error {
+		return api.AddDNSRecord(ctx, recordInput)
+	})
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	data.SetId(recordInputToID(recordInput))
+
+	// Wait for record to be available
+	dnsRecord, err := waitForDNSRecord(ctx, api, recordInput)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if err := refreshDataFromNAPTRRecord(data, *dnsRecord); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+func resourceDNSNAPTRRecordRead(ctx context.Context, data *schema.ResourceData, config interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	api, ok := config.(*cachedDreamhostClient)
+	if !ok {
+		return diag.Errorf("internal error: failed to retrieve dreamhost API client")
+	}
+
+	recordInput, err := idToRecordInput(data.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if recordInput.Type != dreamhostapi.NAPTRRecordType {
+		return diag.Errorf("NAPTR record ID must refer to a NAPTR record, got type %s", recordInput.Type)
+	}
+
+	record, err := api.GetDNSRecord(ctx, *recordInput, true)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	// record is completely missing
+	if record == nil {
+		if data.IsNewResource() {
+			return diag.Errorf("NAPTR record not found: %s", data.Id())
+		}
+		data.SetId("")
+		return diags
+	}
+
+	if err := refreshDataFromNAPTRRecord(data, *record); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return diags
+}
+
+func refreshDataFromNAPTRRecord(data *schema.ResourceData, record dreamhostapi.DNSRecord) error {
+	naptr, err := parseNAPTRRecord(record.Value)
+	if err != nil {
+		return err
+	}
+
+	fields := map[string]interface{}{
+		"record":      record.Record,
+		"order":       naptr.Order,
+		"preference":  naptr.Preference,
+		"flags":       naptr.Flags,
+		"service":     naptr.Service,
+		"regexp":      naptr.Regexp,
+		"replacement": naptr.Replacement,
+		"value":       record.Value,
+	}
+	for key, value := range fields {
+		if err := data.Set(key, value); err != nil {
+			return errors.Wrapf(err, "failed to set field `%s`", key)
+		}
+	}
+
+	return refreshComputedFromRecord(data, record)
+}
@@ -0,0 +1,287 @@
+package dreamhost
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"strings"
+
+	dreamhostapi "github.com/adamantal/go-dreamhost/api"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/pkg/errors"
+)
+
+const (
+	ptrRecordType = dreamhostapi.RecordType("PTR")
+)
+
+func resourceDNSPTRRecord() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceDNSPTRRecordCreate,
+		ReadContext:   resourceDNSPTRRecordRead,
+		UpdateContext: resourceDNSPTRRecordRead,
+		DeleteContext: resourceDNSRecordDelete,
+		CustomizeDiff: resourceDNSPTRRecordCustomizeDiff,
+		Schema: mergeSchemas(map[string]*schema.Schema{
+			"ip_address": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.IsIPAddress,
+				DiffSuppressFunc: func(_, old, new string, _ *schema.ResourceData) bool {
+					return net.ParseIP(old) != nil && net.ParseIP(old).Equal(net.ParseIP(new))
+				},
+				Description: "the IPv4 or IPv6 address the PTR record is published for",
+			},
+			"hostname": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: ValidateDomainName(),
+				DiffSuppressFunc: func(_, old, new string, _ *schema.ResourceData) bool {
+					return strings.EqualFold(strings.TrimSuffix(old, "."), strings.TrimSuffix(new, "."))
+				},
+				Description: "the hostname the address resolves to",
+			},
+			"check_forward": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "warn when the account has no A/AAAA record pointing `hostname` back at `ip_address`",
+			},
+
+			// computed values
+			"record": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "the reverse DNS name the PTR record is published at",
+			},
+			"value": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "the value of the PTR record",
+			},
+		}, dnsRecordComputedSchema()),
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceDNSPTRRecordImport,
+		},
+	}
+}
+
+func resourceDNSPTRRecordCustomizeDiff(ctx context.Context, diff *schema.ResourceDiff, config interface{}) error {
+	if diff.Id() != "" && !diff.HasChange("ip_address") {
+		return nil
+	}
+	if !diff.NewValueKnown("ip_address") {
+		return nil
+	}
+
+	reverseName, err := ptrReverseName(diff.Get("ip_address"))
+	if err != nil {
+		return err
+	}
+	if err := diff.SetNew("record", reverseName); err != nil {
+		return errors.Wrap(err, "failed to set field `record`")
+	}
+
+	api, ok := config.(*cachedDreamhostClient)
+	if !ok {
+		return nil
+	}
+	records, err := api.ListCachedDNSRecords(ctx)
+	if err != nil {
+		return err
+	}
+	return checkReverseZone(records, reverseName)
+}
+
+func resourceDNSPTRRecordCreate(ctx context.Context, data *schema.ResourceData, config interface{}) diag.Diagnostics {
+	api, ok := config.(*cachedDreamhostClient) // nolint:varnamelen
+	if !ok {
+		return diag.Errorf("internal error: failed to retrieve dreamhost API client")
+	}
+
+	reverseName, err := ptrReverseName(data.Get("ip_address"))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	hostname, _ := data.Get("hostname").(string)
+
+	records, err := api.ListCachedDNSRecords(ctx)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if err := checkReverseZone(records, reverseName); err != nil {
+		return diag.FromErr(err)
+	}
+
+	// the value is published fully qualified, GetDNSRecord matches it with or without the trailing dot
+	recordInput := dreamhostapi.DNSRecordInput{
+		Record: reverseName,
+		Value:  dnsFQDN(hostname),
+		Type:   ptrRecordType,
+	}
+
+	// Add record with retry
+	err = retryOnError(ctx, func() error {
+		return api.AddDNSRecord(ctx, recordInput)
+	})
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	data.SetId(recordInputToID(recordInput))
+
+	// Wait for record to be available
+	dnsRecord, err := waitForDNSRecord(ctx, api, recordInput)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	return refreshDataFromPTRRecord(ctx, api, data, *dnsRecord)
+}
+
+func resourceDNSPTRRecordRead(ctx context.Context, data *schema.ResourceData, config interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	api, ok := config.(*cachedDreamhostClient)
+	if !ok {
+		return diag.Errorf("internal error: failed to retrieve dreamhost API client")
+	}
+
+	recordInput, err := idToRecordInput(data.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	record, err := api.GetDNSRecord(ctx, *recordInput, true)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	// record is completely missing
+	if record == nil {
+		if data.IsNewResource() {
+			return diag.Errorf("PTR record not found: %s", data.Id())
+		}
+		data.SetId("")
+		return diags
+	}
+
+	return refreshDataFromPTRRecord(ctx, api, data, *record)
+}
+
+// resourceDNSPTRRecordImport accepts either a DNS record ID or an IP address, in
+// which case the single PTR record published for it is looked up
+func resourceDNSPTRRecordImport(
+	ctx context.Context, data *schema.ResourceData, config interface{},
+) ([]*schema.ResourceData, error) {
+	ip := net.ParseIP(data.Id())
+	if ip == nil {
+		return []*schema.ResourceData{data}, nil
+	}
+
+	api, ok := config.(*cachedDreamhostClient)
+	if !ok {
+		return nil, errors.New("internal error: failed to retrieve dreamhost API client")
+	}
+	reverseName, err := reverseDNSName(ip)
+	if err != nil {
+		return nil, err
+	}
+	records, err := api.ListCachedDNSRecords(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var found []dreamhostapi.DNSRecord
+	for _, record := range records {
+		if record.Type == ptrRecordType && strings.EqualFold(record.Record, reverseName) {
+			found = append(found, record)
+		}
+	}
+	switch len(found) {
+	case 0:
+		return nil, errors.Errorf("no PTR record found for %s at %s", data.Id(), reverseName)
+	case 1:
+	default:
+		return nil, errors.Errorf("multiple PTR records found for %s, import one by its record ID", data.Id())
+	}
+
+	data.SetId(recordInputToID(dreamhostapi.DNSRecordInput{
+		Record: found[0].Record,
+		Type:   found[0].Type,
+		Value:  found[0].Value,
+	}))
+
+	return []*schema.ResourceData{data}, nil
+}
+
+// refreshDataFromPTRRecord sets the attributes from the published record and
+// optionally warns about a missing forward record
+func refreshDataFromPTRRecord(
+	ctx context.Context, api *cachedDreamhostClient, data *schema.ResourceData, record dreamhostapi.DNSRecord,
+) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	ip, err := ipFromReverseDNSName(record.Record)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	fields := map[string]interface{}{
+		"ip_address": ip.String(),
+		"hostname":   strings.TrimSuffix(record.Value, "."),
+		"record":     record.Record,
+		"value":      record.Value,
+	}
+	for key, value := range fields {
+		if err := data.Set(key, value); err != nil {
+			return diag.Errorf("failed to set field `%s`: %v", key, err)
+		}
+	}
+	if err := refreshComputedFromRecord(data, record); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if checkForward, _ := data.Get("check_forward").(bool); checkForward {
+		records, err := api.ListCachedDNSRecords(ctx)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		hostname := strings.TrimSuffix(record.Value, ".")
+		if !hasForwardRecord(records, hostname, ip) {
+			diags = append(diags, diag.Diagnostic{
+				Severity: diag.Warning,
+				Summary:  "No matching forward DNS record",
+				Detail: fmt.Sprintf("The account has no A/AAAA record for %s pointing at %s; "+
+					"many mail servers reject hosts whose reverse and forward DNS do not match.", hostname, ip),
+			})
+		}
+	}
+
+	return diags
+}
+
+// ptrReverseName computes the reverse DNS name of the ip_address attribute
+func ptrReverseName(value interface{}) (string, error) {
+	address, _ := value.(string)
+	ip := net.ParseIP(address)
+	if ip == nil {
+		return "", fmt.Errorf("%s is not a valid IP address", address)
+	}
+	return reverseDNSName(ip)
+}
+
+// checkReverseZone returns an error if the reverse name is outside of the account's zones
+func checkReverseZone(records []dreamhostapi.DNSRecord, reverseName string) error {
+	if findReverseZone(records, reverseName) == "" {
+		return errors.Errorf(
+			"%s is not inside any reverse zone of the account; delegate the reverse zone to DreamHost first",
+			reverseName,
+		)
+	}
+	return nil
+}
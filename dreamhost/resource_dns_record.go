@@ -22,7 +22,7 @@ func resourceDNSRecord() *schema.Resource {
 		ReadContext:   resourceDNSRecordRead,
 		UpdateContext: nil,
 		DeleteContext: resourceDNSRecordDelete,
-		Schema: map[string]*schema.Schema{
+		Schema: mergeSchemas(map[string]*schema.Schema{
 			"record": {
 				Type:        schema.TypeString,
 				Required:    true,
@@ -44,35 +44,50 @@ func resourceDNSRecord() *schema.Resource {
 				}, false),
 				Description: "the type of the DNS record (e.g. A, AAAA, CNAME, MX, NS, PTR, TXT, SRV, NAPTR)",
 			},
-
-			// computed values
-			"comment": {
-				Type:        schema.TypeString,
-				Computed:    true,
-				Description: "any comment attached to the DNS record",
-			},
-			"account_id": {
-				Type:        schema.TypeString,
-				Computed:    true,
-				Description: "the account ID belonging to the DNS record",
-			},
-			"zone": {
-				Type:        schema.TypeString,
-				Computed:    true,
-				Description: "the zone of the DNS record (used in a multi-zone setup)",
-			},
-			"editable": {
-				Type:        schema.TypeString,
-				Computed:    true,
-				Description: "whether the record is editable",
-			},
-		},
+		}, dnsRecordComputedSchema()),
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
 	}
 }
 
+// dnsRecordComputedSchema returns the read-only attributes DreamHost reports for every DNS record
+func dnsRecordComputedSchema() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"comment": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "any comment attached to the DNS record",
+		},
+		"account_id": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "the account ID belonging to the DNS record",
+		},
+		"zone": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "the zone of the DNS record (used in a multi-zone setup)",
+		},
+		"editable": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "whether the record is editable",
+		},
+	}
+}
+
+// mergeSchemas combines attribute maps into a single resource schema
+func mergeSchemas(schemas ...map[string]*schema.Schema) map[string]*schema.Schema {
+	result := map[string]*schema.Schema{}
+	for _, s := range schemas {
+		for k, v := range s {
+			result[k] = v
+		}
+	}
+	return result
+}
+
 func resourceDNSRecordCreate(ctx context.Context, data *schema.ResourceData, config interface{}) diag.Diagnostics {
 	api, ok := config.(*cachedDreamhostClient) // nolint:varnamelen
 	if !ok {
@@ -174,7 +189,11 @@ func refreshDataFromRecord(data *schema.ResourceData, record dreamhostapi.DNSRec
 		return errors.Wrap(err, "failed to set field `type`")
 	}
 
-	// computed values
+	return refreshComputedFromRecord(data, record)
+}
+
+// refreshComputedFromRecord sets the read-only attributes shared by all DNS record resources
+func refreshComputedFromRecord(data *schema.ResourceData, record dreamhostapi.DNSRecord) error {
 	if err := data.Set("comment", record.Comment); err != nil {
 		return errors.Wrap(err, "failed to set field `comment`")
 	}
@@ -246,3 +265,37 @@ func idToRecordInput(id string) (*dreamhostapi.DNSRecordInput, error) {
 		Value:  parts[2],
 	}, nil
 }
+
+// txtLengthWarnings warns about TXT values longer than a single character-string.
+// Values are passed to the API as they are, the provider does not split them.
+func txtLengthWarnings(kind, value string) diag.Diagnostics {
+	if len(value) <= maxTXTStringLength {
+		return nil
+	}
+	return diag.Diagnostics{{
+		Severity: diag.Warning,
+		Summary:  kind + " record exceeds TXT string length",
+		Detail: fmt.Sprintf("The %s record is %d characters long, which is more than the %d characters "+
+			"a single TXT string can hold. Receivers only read it if the DNS server splits it into several "+
+			"strings.", kind, len(value), maxTXTStringLength),
+	}}
+}
+
+// findTXTRecords returns the TXT records published at name whose value satisfies
+// match, skipping the record with the given resource ID
+func findTXTRecords(
+	records []dreamhostapi.DNSRecord, name, skipID string, match func(string) bool,
+) []dreamhostapi.DNSRecord {
+	var found []dreamhostapi.DNSRecord
+	for _, record := range records {
+		if record.Type != dreamhostapi.TXTRecordType || !strings.EqualFold(record.Record, name) || !match(record.Value) {
+			continue
+		}
+		id := recordInputToID(dreamhostapi.DNSRecordInput{Record: record.Record, Type: record.Type, Value: record.Value})
+		if id == skipID {
+			continue
+		}
+		found = append(found, record)
+	}
+	return found
+}
@@ -0,0 +1,282 @@
+package dreamhost
+
+import (
+	"context"
+
+	dreamhostapi "github.com/adamantal/go-dreamhost/api"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/pkg/errors"
+)
+
+// spfArguments are the configurable attributes the SPF value is rendered from
+var spfArguments = []string{"record", "a", "mx", "ip4", "ip6", "include", "redirect", "all"} // nolint:gochecknoglobals
+
+func resourceDNSSPFRecord() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceDNSSPFRecordCreate,
+		ReadContext:   resourceDNSSPFRecordRead,
+		UpdateContext: nil,
+		DeleteContext: resourceDNSRecordDelete,
+		CustomizeDiff: resourceDNSSPFRecordCustomizeDiff,
+		Schema: mergeSchemas(map[string]*schema.Schema{
+			"record": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "the name the SPF record is published at",
+			},
+			"a": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				ForceNew:    true,
+				Description: "domains whose A/AAAA records are authorized senders (`@` for the record itself)",
+				Elem: &schema.Schema{
+					Type:         schema.TypeString,
+					ValidateFunc: validation.Any(validation.StringInSlice([]string{"@"}, false), ValidateDomainName()),
+				},
+			},
+			"mx": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				ForceNew:    true,
+				Description: "domains whose MX hosts are authorized senders (`@` for the record itself)",
+				Elem: &schema.Schema{
+					Type:         schema.TypeString,
+					ValidateFunc: validation.Any(validation.StringInSlice([]string{"@"}, false), ValidateDomainName()),
+				},
+			},
+			"ip4": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				ForceNew:    true,
+				Description: "IPv4 addresses or CIDR blocks authorized to send",
+				Elem: &schema.Schema{
+					Type:         schema.TypeString,
+					ValidateFunc: ValidateIPv4Network(),
+				},
+			},
+			"ip6": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				ForceNew:    true,
+				Description: "IPv6 addresses or CIDR blocks authorized to send",
+				Elem: &schema.Schema{
+					Type:         schema.TypeString,
+					ValidateFunc: ValidateIPv6Network(),
+				},
+			},
+			"include": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				ForceNew:    true,
+				Description: "domains whose SPF policy is included",
+				Elem: &schema.Schema{
+					Type:         schema.TypeString,
+					ValidateFunc: ValidateDomainName(),
+				},
+			},
+			"redirect": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				ForceNew:      true,
+				ValidateFunc:  ValidateDomainName(),
+				ConflictsWith: []string{"all"},
+				Description:   "a domain whose SPF policy replaces this one",
+			},
+			"all": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				ForceNew:      true,
+				ValidateFunc:  validation.StringInSlice([]string{"-all", "~all", "?all", "+all"}, false),
+				ConflictsWith: []string{"redirect"},
+				Description:   "the result for senders not matched by any mechanism (-all, ~all, ?all or +all)",
+			},
+
+			// computed values
+			"value": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "the rendered TXT record value",
+			},
+			"lookup_count": {
+				Type:     schema.TypeInt,
+				Computed: true,
+				Description: "the number of DNS lookups the record requires; includes and redirects are only followed " +
+					"into the account's own zones",
+			},
+		}, dnsRecordComputedSchema()),
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+	}
+}
+
+func resourceDNSSPFRecordCustomizeDiff(ctx context.Context, diff *schema.ResourceDiff, config interface{}) error {
+	if diff.Id() != "" && !diff.HasChanges(spfArguments...) {
+		return nil
+	}
+	for _, key := range spfArguments {
+		if !diff.NewValueKnown(key) {
+			return nil
+		}
+	}
+
+	spf := spfRecordFromConfig(diff)
+	if err := diff.SetNew("value", spf.String()); err != nil {
+		return errors.Wrap(err, "failed to set field `value`")
+	}
+
+	api, ok := config.(*cachedDreamhostClient)
+	if !ok {
+		return nil
+	}
+	records, err := api.ListCachedDNSRecords(ctx)
+	if err != nil {
+		return err
+	}
+
+	// the record being replaced is deleted before its successor is created
+	record, _ := diff.Get("record").(string)
+	if err := checkDuplicateSPF(records, record, diff.Id()); err != nil {
+		return err
+	}
+
+	lookups, _ := countSPFLookups(&spf, records)
+	if err := diff.SetNew("lookup_count", lookups); err != nil {
+		return errors.Wrap(err, "failed to set field `lookup_count`")
+	}
+
+	return nil
+}
+
+func resourceDNSSPFRecordCreate(ctx context.Context, data *schema.ResourceData, config interface{}) diag.Diagnostics {
+	api, ok := config.(*cachedDreamhostClient) // nolint:varnamelen
+	if !ok {
+		return diag.Errorf("internal error: failed to retrieve dreamhost API client")
+	}
+
+	record, ok := data.Get("record").(string)
+	if !ok {
+		return diag.Errorf("internal error: failed to retrieve record property for SPF record creation")
+	}
+
+	records, err := api.ListCachedDNSRecords(ctx)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if err := checkDuplicateSPF(records, record, ""); err != nil {
+		return diag.FromErr(err)
+	}
+
+	recordInput := dreamhostapi.DNSRecordInput{
+		Record: record,
+		Value:  spfRecordFromConfig(data).String(),
+		Type:   dreamhostapi.TXTRecordType,
+	}
+
+	// Add record with retry
+	err = retryOnError(ctx, func() error {
+		return api.AddDNSRecord(ctx, recordInput)
+	})
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	data.SetId(recordInputToID(recordInput))
+
+	// Wait for record to be available
+	dnsRecord, err := waitForDNSRecord(ctx, api, recordInput)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	return refreshDataFromSPFRecord(ctx, api, data, *dnsRecord)
+}
+
+func resourceDNSSPFRecordRead(ctx context.Context, data *schema.ResourceData, config interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	api, ok := config.(*cachedDreamhostClient)
+	if !ok {
+		return diag.Errorf("internal error: failed to retrieve dreamhost API client")
+	}
+
+	recordInput, err := idToRecordInput(data.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if recordInput.Type != dreamhostapi.TXTRecordType {
+		return diag.Errorf("SPF record ID must refer to a TXT record, got type %s", recordInput.Type)
+	}
+
+	record, err := api.GetDNSRecord(ctx, *recordInput, true)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	// record is completely missing
+	if record == nil {
+		if data.IsNewResource() {
+			return diag.Errorf("SPF record not found: %s", data.Id())
+		}
+		data.SetId("")
+		return diags
+	}
+
+	return refreshDataFromSPFRecord(ctx, api, data, *record)
+}
+
+// refreshDataFromSPFRecord sets the structured SPF attributes from the published
+// record and reports any problems with the value as warnings
+func refreshDataFromSPFRecord(
+	ctx context.Context, api *cachedDreamhostClient, data *schema.ResourceData, record dreamhostapi.DNSRecord,
+) diag.Diagnostics {
+	spf, err := parseSPFRecord(record.Value)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	records, err := api.ListCachedDNSRecords(ctx)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	lookups, exact := countSPFLookups(spf, records)
+
+	fields := map[string]interface{}{
+		"record":       record.Record,
+		"a":            spf.A,
+		"mx":           spf.MX,
+		"ip4":          spf.IP4,
+		"ip6":          spf.IP6,
+		"include":      spf.Include,
+		"redirect":     spf.Redirect,
+		"all":          spf.All,
+		"value":        record.Value,
+		"lookup_count": lookups,
+	}
+	for key, value := range fields {
+		if err := data.Set(key, value); err != nil {
+			return diag.Errorf("failed to set field `%s`: %v", key, err)
+		}
+	}
+	if err := refreshComputedFromRecord(data, record); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return spfWarnings(record.Value, lookups, exact)
+}
+
+// spfRecordFromConfig builds the structured SPF record from resource data or a plan
+func spfRecordFromConfig(data interface{ Get(string) interface{} }) spfRecord {
+	spf := spfRecord{}
+	spf.A = expandStringList(data.Get("a").([]interface{}))
+	spf.MX = expandStringList(data.Get("mx").([]interface{}))
+	spf.IP4 = expandStringList(data.Get("ip4").([]interface{}))
+	spf.IP6 = expandStringList(data.Get("ip6").([]interface{}))
+	spf.Include = expandStringList(data.Get("include").([]interface{}))
+	spf.Redirect, _ = data.Get("redirect").(string)
+	spf.All, _ = data.Get("all").(string)
+	return spf
+}
@@ -0,0 +1,214 @@
+package dreamhost
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/pkg/errors"
+)
+
+func resourceMailFilter() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceMailFilterCreate,
+		ReadContext:   resourceMailFilterRead,
+		UpdateContext: nil,
+		DeleteContext: resourceMailFilterDelete,
+		CustomizeDiff: resourceMailFilterCustomizeDiff,
+		Schema: map[string]*schema.Schema{
+			"address": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: ValidateEmailAddress(),
+				Description:  "the mailbox the filter applies to",
+			},
+			"filter_on": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.StringInSlice(mailFilterFields, false),
+				Description:  "the part of the message to match (subject, from, to, cc, body, reply-to or headers)",
+			},
+			"filter": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "the text to look for",
+			},
+			"action": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.StringInSlice(mailFilterActions, false),
+				Description:  "what to do with matching messages (move, forward, delete, add_subject, forward_shell, and or or)",
+			},
+			"action_value": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				ForceNew:    true,
+				Description: "the folder, address or subject prefix the action uses",
+			},
+			"contains": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				ForceNew:    true,
+				Default:     true,
+				Description: "match messages containing `filter`, or not containing it when false",
+			},
+			"stop": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				ForceNew:    true,
+				Default:     true,
+				Description: "stop processing further filters after this one matches",
+			},
+
+			// computed values
+			"rank": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "the position of the filter in the mailbox's filter list",
+			},
+		},
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+	}
+}
+
+func resourceMailFilterCustomizeDiff(_ context.Context, diff *schema.ResourceDiff, _ interface{}) error {
+	if !diff.NewValueKnown("action") || !diff.NewValueKnown("action_value") {
+		return nil
+	}
+	return validateMailFilter(mailFilterFromConfig(diff))
+}
+
+// mailFilterFromConfig builds the filter from the resource configuration
+func mailFilterFromConfig(data interface{ Get(string) interface{} }) mailFilter {
+	filter := mailFilter{}
+	filter.Address, _ = data.Get("address").(string)
+	filter.FilterOn, _ = data.Get("filter_on").(string)
+	filter.Filter, _ = data.Get("filter").(string)
+	filter.Action, _ = data.Get("action").(string)
+	filter.ActionValue, _ = data.Get("action_value").(string)
+	contains, _ := data.Get("contains").(bool)
+	stop, _ := data.Get("stop").(bool)
+	filter.Contains, filter.Stop = apiFlag(contains), apiFlag(stop)
+	return filter
+}
+
+func resourceMailFilterCreate(ctx context.Context, data *schema.ResourceData, config interface{}) diag.Diagnostics {
+	api, ok := config.(*cachedDreamhostClient) // nolint:varnamelen
+	if !ok {
+		return diag.Errorf("internal error: failed to retrieve dreamhost API client")
+	}
+
+	filter := mailFilterFromConfig(data)
+	if err := validateMailFilter(filter); err != nil {
+		return diag.FromErr(err)
+	}
+
+	existing, err := findMailFilter(ctx, api, filter)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if existing != nil {
+		return diag.Errorf("mail filter already exists, import it with ID %q", mailFilterToID(filter))
+	}
+
+	if err := api.CallCommand(ctx, addFilterCmd, filter.params(), nil); err != nil {
+		return diag.FromErr(err)
+	}
+
+	data.SetId(mailFilterToID(filter))
+
+	return resourceMailFilterRead(ctx, data, config)
+}
+
+func resourceMailFilterRead(ctx context.Context, data *schema.ResourceData, config interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	api, ok := config.(*cachedDreamhostClient)
+	if !ok {
+		return diag.Errorf("internal error: failed to retrieve dreamhost API client")
+	}
+
+	filter, err := idToMailFilter(data.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	found, err := findMailFilter(ctx, api, *filter)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	// filter is completely missing
+	if found == nil {
+		if data.IsNewResource() {
+			return diag.Errorf("mail filter not found: %s", data.Id())
+		}
+		data.SetId("")
+		return diags
+	}
+
+	if err := refreshDataFromMailFilter(data, *found); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return diags
+}
+
+func resourceMailFilterDelete(ctx context.Context, data *schema.ResourceData, config interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	api, ok := config.(*cachedDreamhostClient)
+	if !ok {
+		return diag.Errorf("internal error: failed to retrieve dreamhost API client")
+	}
+
+	filter, err := idToMailFilter(data.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	// remove the filter as listed, the API expects the exact stored values
+	found, err := findMailFilter(ctx, api, *filter)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if found == nil {
+		return diags
+	}
+
+	if err := api.CallCommand(ctx, removeFilterCmd, found.params(), nil); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return diags
+}
+
+func refreshDataFromMailFilter(data *schema.ResourceData, filter mailFilter) error {
+	rank, _ := strconv.Atoi(filter.Rank)
+
+	fields := map[string]interface{}{
+		"address":      filter.Address,
+		"filter_on":    filter.FilterOn,
+		"filter":       filter.Filter,
+		"action":       filter.Action,
+		"action_value": filter.ActionValue,
+		"contains":     isAPIFlagSet(filter.Contains),
+		"stop":         isAPIFlagSet(filter.Stop),
+		"rank":         rank,
+	}
+	for key, value := range fields {
+		if err := data.Set(key, value); err != nil {
+			return errors.Wrapf(err, "failed to set field `%s`", key)
+		}
+	}
+
+	return nil
+}
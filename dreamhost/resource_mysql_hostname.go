@@ -0,0 +1,133 @@
+package dreamhost
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/pkg/errors"
+)
+
+func resourceMySQLHostname() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceMySQLHostnameCreate,
+		ReadContext:   resourceMySQLHostnameRead,
+		UpdateContext: nil,
+		DeleteContext: resourceMySQLHostnameDelete,
+		Schema: map[string]*schema.Schema{
+			"hostname": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: ValidateDomainName(),
+				Description:  "the hostname pointing to the database server, e.g. mysql.example.com",
+			},
+			"home": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "the database server the hostname points to, as hostname or short name",
+			},
+		},
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+	}
+}
+
+func resourceMySQLHostnameCreate(ctx context.Context, data *schema.ResourceData, config interface{}) diag.Diagnostics {
+	api, ok := config.(*cachedDreamhostClient) // nolint:varnamelen
+	if !ok {
+		return diag.Errorf("internal error: failed to retrieve dreamhost API client")
+	}
+
+	hostname, _ := data.Get("hostname").(string)
+	home, _ := data.Get("home").(string)
+	hostname = normalizeDNSName(hostname)
+
+	existing, err := findMySQLHostname(ctx, api, hostname)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if existing != nil {
+		return diag.Errorf("MySQL hostname already exists, import it with ID %q", hostname)
+	}
+
+	if err := api.CallCommand(ctx, addMySQLHostnameCmd, mysqlHostnameParams(hostname, home), nil); err != nil {
+		return diag.FromErr(err)
+	}
+
+	// the hostname exists from now on, keep it in state even if waiting for it fails
+	data.SetId(hostname)
+
+	if _, err := waitForMySQLHostname(ctx, api, hostname); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return resourceMySQLHostnameRead(ctx, data, config)
+}
+
+func resourceMySQLHostnameRead(ctx context.Context, data *schema.ResourceData, config interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	api, ok := config.(*cachedDreamhostClient)
+	if !ok {
+		return diag.Errorf("internal error: failed to retrieve dreamhost API client")
+	}
+
+	found, err := findMySQLHostname(ctx, api, data.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	// hostname is completely missing
+	if found == nil {
+		if data.IsNewResource() {
+			return diag.Errorf("MySQL hostname not found: %s", data.Id())
+		}
+		data.SetId("")
+		return diags
+	}
+
+	// keep the configured short server name when it refers to the listed server
+	home, _ := data.Get("home").(string)
+	if home == "" || !matchesServer(found.Home, home) {
+		home = found.Home
+	}
+
+	fields := map[string]interface{}{
+		"hostname": normalizeDNSName(found.Domain),
+		"home":     home,
+	}
+	for key, value := range fields {
+		if err := data.Set(key, value); err != nil {
+			return diag.FromErr(errors.Wrapf(err, "failed to set field `%s`", key))
+		}
+	}
+
+	return diags
+}
+
+func resourceMySQLHostnameDelete(ctx context.Context, data *schema.ResourceData, config interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	api, ok := config.(*cachedDreamhostClient)
+	if !ok {
+		return diag.Errorf("internal error: failed to retrieve dreamhost API client")
+	}
+
+	err := api.CallCommand(ctx, removeMySQLHostnameCmd, mysqlHostnameParams(data.Id(), ""), nil)
+	// a hostname that is already gone is as good as removed
+	if isCommandError(err, mysqlHostnameNotFoundCode) {
+		return diags
+	}
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := waitForMySQLHostnameDeletion(ctx, api, data.Id()); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return diags
+}
@@ -0,0 +1,209 @@
+package dreamhost
+
+import (
+	"context"
+	"net/url"
+	"sort"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/pkg/errors"
+)
+
+// mysqlPasswordStateValue stands in for the password in state, nothing derived
+// from the password itself is stored
+const mysqlPasswordStateValue = "managed"
+
+func resourceMySQLUser() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceMySQLUserCreate,
+		ReadContext:   resourceMySQLUserRead,
+		UpdateContext: nil,
+		DeleteContext: resourceMySQLUserDelete,
+		Schema: map[string]*schema.Schema{
+			"database": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "the database the user is granted access to",
+			},
+			"username": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.StringIsNotWhiteSpace,
+				Description:  "the MySQL username",
+			},
+			"password": {
+				Type:             schema.TypeString,
+				Required:         true,
+				ForceNew:         true,
+				Sensitive:        true,
+				ValidateFunc:     validation.StringIsNotEmpty,
+				StateFunc:        omitMySQLPassword,
+				DiffSuppressFunc: suppressMySQLPasswordDiff,
+				Description: "the password of the user; it is not stored in state, so changes are only " +
+					"applied when `password_version` changes",
+			},
+			"password_version": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				ForceNew:    true,
+				Description: "changing this value recreates the user with the current `password`",
+			},
+			"hostnames": {
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Computed:    true,
+				ForceNew:    true,
+				Description: "the host patterns the user may connect from, `%` (any host) by default",
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+			"privileges": {
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Computed:    true,
+				ForceNew:    true,
+				Description: "the granted privileges (select, insert, update, delete, create, drop, index, alter), all by default",
+				Elem: &schema.Schema{
+					Type:         schema.TypeString,
+					ValidateFunc: validation.StringInSlice(mysqlPrivileges, false),
+				},
+			},
+
+			// computed values
+			"home": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "the database server hosting the database",
+			},
+		},
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+	}
+}
+
+// omitMySQLPassword is the state representation of the password; the configured
+// value only reaches Create through the planned diff
+func omitMySQLPassword(_ interface{}) string {
+	return mysqlPasswordStateValue
+}
+
+// suppressMySQLPasswordDiff ignores password changes of existing users, including
+// imported users whose password is unknown; bumping password_version replaces the
+// user and applies the current password
+func suppressMySQLPasswordDiff(_, _, _ string, d *schema.ResourceData) bool {
+	return d.Id() != ""
+}
+
+func resourceMySQLUserCreate(ctx context.Context, data *schema.ResourceData, config interface{}) diag.Diagnostics {
+	api, ok := config.(*cachedDreamhostClient) // nolint:varnamelen
+	if !ok {
+		return diag.Errorf("internal error: failed to retrieve dreamhost API client")
+	}
+
+	database, _ := data.Get("database").(string)
+	username, _ := data.Get("username").(string)
+	// the planned value is the placeholder, reading the attribute returns the configured password
+	password, _ := data.Get("password").(string)
+
+	hosts := []string{mysqlAnyHost}
+	if set, ok := data.Get("hostnames").(*schema.Set); ok && set.Len() > 0 {
+		hosts = expandStringList(set.List())
+		sort.Strings(hosts)
+	}
+	privileges := mysqlPrivileges
+	if set, ok := data.Get("privileges").(*schema.Set); ok && set.Len() > 0 {
+		privileges = expandStringList(set.List())
+	}
+
+	existing, err := listMySQLUsers(ctx, api, "", database, username)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if len(existing) > 0 {
+		return diag.Errorf("MySQL user already exists, import it with ID %q", mysqlUserToID(database, username))
+	}
+
+	params := mysqlUserParams(database, username, password, hosts, privileges)
+	if err := api.CallCommand(ctx, addMySQLUserCmd, params, nil); err != nil {
+		return diag.FromErr(err)
+	}
+
+	data.SetId(mysqlUserToID(database, username))
+
+	return resourceMySQLUserRead(ctx, data, config)
+}
+
+func resourceMySQLUserRead(ctx context.Context, data *schema.ResourceData, config interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	api, ok := config.(*cachedDreamhostClient)
+	if !ok {
+		return diag.Errorf("internal error: failed to retrieve dreamhost API client")
+	}
+
+	database, username, err := idToMySQLUser(data.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	grants, err := listMySQLUsers(ctx, api, "", database, username)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	// user is completely missing
+	if len(grants) == 0 {
+		if data.IsNewResource() {
+			return diag.Errorf("MySQL user not found: %s", data.Id())
+		}
+		data.SetId("")
+		return diags
+	}
+
+	hosts := make([]string, 0, len(grants))
+	for _, grant := range grants {
+		hosts = append(hosts, grant.Host)
+	}
+
+	fields := map[string]interface{}{
+		"database":   database,
+		"username":   username,
+		"hostnames":  hosts,
+		"privileges": grants[0].privileges(),
+		"home":       grants[0].Home,
+	}
+	for key, value := range fields {
+		if err := data.Set(key, value); err != nil {
+			return diag.FromErr(errors.Wrapf(err, "failed to set field `%s`", key))
+		}
+	}
+
+	return diags
+}
+
+func resourceMySQLUserDelete(ctx context.Context, data *schema.ResourceData, config interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	api, ok := config.(*cachedDreamhostClient)
+	if !ok {
+		return diag.Errorf("internal error: failed to retrieve dreamhost API client")
+	}
+
+	database, username, err := idToMySQLUser(data.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	params := url.Values{"db": {database}, "user": {username}}
+	// a user that is already gone is as good as removed
+	if err := api.CallCommand(ctx, removeMySQLUserCmd, params, nil); err != nil &&
+		!isCommandError(err, mysqlUserNotFoundCode) {
+		return diag.FromErr(err)
+	}
+
+	return diags
+}
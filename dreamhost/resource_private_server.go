@@ -0,0 +1,235 @@
+package dreamhost
+
+import (
+	"context"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/pkg/errors"
+)
+
+const minPrivateServerMemory = 300
+
+func resourcePrivateServer() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourcePrivateServerCreate,
+		ReadContext:   resourcePrivateServerRead,
+		UpdateContext: resourcePrivateServerUpdate,
+		DeleteContext: resourcePrivateServerDelete,
+		Schema: map[string]*schema.Schema{
+			"type": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.StringInSlice(privateServerTypes, false),
+				Description:  "the server type (web or mysql)",
+			},
+			"movedata": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				ForceNew:    true,
+				Default:     false,
+				Description: "move the existing shared hosting data to the new server",
+			},
+			"memory_mb": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: validation.IntAtLeast(minPrivateServerMemory),
+				Description:  "the memory size in megabytes; changing it resizes the server in place",
+			},
+
+			// computed values
+			"name": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "the name of the server, e.g. ps12345",
+			},
+			"description": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "the description of the server",
+			},
+			"status": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "the status of the server",
+			},
+			"ip": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "the IP address of the server",
+			},
+			"start_date": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "the date the server was added",
+			},
+		},
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(defaultPrivateServerTimeout),
+			Update: schema.DefaultTimeout(defaultPrivateServerTimeout),
+			Delete: schema.DefaultTimeout(defaultPrivateServerTimeout),
+		},
+	}
+}
+
+func resourcePrivateServerCreate(ctx context.Context, data *schema.ResourceData, config interface{}) diag.Diagnostics {
+	api, ok := config.(*cachedDreamhostClient) // nolint:varnamelen
+	if !ok {
+		return diag.Errorf("internal error: failed to retrieve dreamhost API client")
+	}
+
+	serverType, _ := data.Get("type").(string)
+	movedata, _ := data.Get("movedata").(bool)
+	memory, _ := data.Get("memory_mb").(int)
+
+	// the creation waits share the create timeout
+	deadline := time.Now().Add(data.Timeout(schema.TimeoutCreate))
+
+	server, err := addPrivateServer(ctx, api, serverType, movedata, time.Until(deadline))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	// the server exists from here on, so it is tracked even if provisioning fails
+	data.SetId(server.Name)
+
+	if err := waitForPrivateServerProvisioning(ctx, api, server, time.Until(deadline)); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if memory != 0 && memory != server.memory() {
+		if err := resizePrivateServer(ctx, api, server.Name, memory, time.Until(deadline)); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	return resourcePrivateServerRead(ctx, data, config)
+}
+
+func resourcePrivateServerRead(ctx context.Context, data *schema.ResourceData, config interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	api, ok := config.(*cachedDreamhostClient)
+	if !ok {
+		return diag.Errorf("internal error: failed to retrieve dreamhost API client")
+	}
+
+	server, err := findPrivateServer(ctx, api, data.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	// server is completely missing
+	if server == nil {
+		if data.IsNewResource() {
+			return diag.Errorf("private server not found: %s", data.Id())
+		}
+		data.SetId("")
+		return diags
+	}
+
+	fields := map[string]interface{}{
+		"type":        server.Type,
+		"memory_mb":   server.memory(),
+		"name":        server.Name,
+		"description": server.Description,
+		"status":      server.Status,
+		"ip":          server.IP,
+		"start_date":  server.StartDate,
+	}
+	for key, value := range fields {
+		if err := data.Set(key, value); err != nil {
+			return diag.FromErr(errors.Wrapf(err, "failed to set field `%s`", key))
+		}
+	}
+
+	return diags
+}
+
+func resourcePrivateServerUpdate(ctx context.Context, data *schema.ResourceData, config interface{}) diag.Diagnostics {
+	api, ok := config.(*cachedDreamhostClient)
+	if !ok {
+		return diag.Errorf("internal error: failed to retrieve dreamhost API client")
+	}
+
+	if data.HasChange("memory_mb") {
+		memory, _ := data.Get("memory_mb").(int)
+		if err := resizePrivateServer(ctx, api, data.Id(), memory, data.Timeout(schema.TimeoutUpdate)); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	return resourcePrivateServerRead(ctx, data, config)
+}
+
+func resourcePrivateServerDelete(ctx context.Context, data *schema.ResourceData, config interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	api, ok := config.(*cachedDreamhostClient)
+	if !ok {
+		return diag.Errorf("internal error: failed to retrieve dreamhost API client")
+	}
+
+	err := api.CallCommand(ctx, removePrivateServerCmd, privateServerParams(data.Id()), nil)
+	// a server that is already gone is as good as removed
+	if isCommandError(err, privateServerNotFoundCode) {
+		return diags
+	}
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := waitForPrivateServerDeletion(ctx, api, data.Id(), data.Timeout(schema.TimeoutDelete)); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return diags
+}
+
+// resizePrivateServer sets the memory size and waits for the server to report it
+func resizePrivateServer(
+	ctx context.Context, api *cachedDreamhostClient, name string, memory int, timeout time.Duration,
+) error {
+	params := privateServerParams(name)
+	params.Set("size", strconv.Itoa(memory))
+	if err := api.CallCommand(ctx, setPrivateServerSizeCmd, params, nil); err != nil {
+		return err
+	}
+	return waitForPrivateServerSize(ctx, api, name, memory, timeout)
+}
+
+// addPrivateServer requests a server and waits until it can be identified. The API
+// does not return the name of the new server, it is the one that was not there
+// before, so creations are serialized and compared against a fresh listing.
+func addPrivateServer(
+	ctx context.Context, api *cachedDreamhostClient, serverType string, movedata bool, timeout time.Duration,
+) (*privateServer, error) {
+	api.privateServerCreates.Lock()
+	defer api.privateServerCreates.Unlock()
+
+	api.cache.InvalidateCommands()
+	known, err := privateServerNames(ctx, api)
+	if err != nil {
+		return nil, err
+	}
+
+	params := url.Values{"type": {serverType}, "movedata": {apiFlag(movedata)}}
+	if err := api.CallCommand(ctx, addPrivateServerCmd, params, nil); err != nil {
+		return nil, err
+	}
+
+	server, err := waitForPrivateServer(ctx, api, serverType, known, timeout)
+	if err != nil {
+		return nil, errors.Wrap(err, "the requested server may still be created, import it once it is listed")
+	}
+
+	return server, nil
+}
@@ -0,0 +1,163 @@
+package dreamhost
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/pkg/errors"
+)
+
+func resourcePrivateServerSettings() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourcePrivateServerSettingsCreate,
+		ReadContext:   resourcePrivateServerSettingsRead,
+		UpdateContext: resourcePrivateServerSettingsUpdate,
+		DeleteContext: resourcePrivateServerSettingsDelete,
+		Schema: map[string]*schema.Schema{
+			"ps": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "the name of the private server, e.g. ps12345",
+			},
+			"settings": {
+				Type:        schema.TypeMap,
+				Required:    true,
+				Description: "the settings to manage; settings not listed here are left alone",
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+		},
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+	}
+}
+
+// expandSettings converts a Terraform map of strings into a string map
+func expandSettings(value interface{}) map[string]string {
+	raw, _ := value.(map[string]interface{})
+	settings := make(map[string]string, len(raw))
+	for key, v := range raw {
+		settings[key], _ = v.(string)
+	}
+	return settings
+}
+
+// setPrivateServerSettings applies the settings to the server
+func setPrivateServerSettings(
+	ctx context.Context, api *cachedDreamhostClient, name string, settings map[string]string,
+) error {
+	if len(settings) == 0 {
+		return nil
+	}
+	params := privateServerParams(name)
+	for key, value := range settings {
+		params.Set(key, value)
+	}
+	if err := api.CallCommand(ctx, setPrivateServerSettingsCmd, params, nil); err != nil {
+		return errors.Wrapf(err, "failed to set settings of private server %s", name)
+	}
+	return nil
+}
+
+func resourcePrivateServerSettingsCreate(
+	ctx context.Context, data *schema.ResourceData, config interface{},
+) diag.Diagnostics {
+	api, ok := config.(*cachedDreamhostClient) // nolint:varnamelen
+	if !ok {
+		return diag.Errorf("internal error: failed to retrieve dreamhost API client")
+	}
+
+	name, _ := data.Get("ps").(string)
+	if err := setPrivateServerSettings(ctx, api, name, expandSettings(data.Get("settings"))); err != nil {
+		return diag.FromErr(err)
+	}
+
+	data.SetId(name)
+
+	return resourcePrivateServerSettingsRead(ctx, data, config)
+}
+
+func resourcePrivateServerSettingsRead(
+	ctx context.Context, data *schema.ResourceData, config interface{},
+) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	api, ok := config.(*cachedDreamhostClient)
+	if !ok {
+		return diag.Errorf("internal error: failed to retrieve dreamhost API client")
+	}
+
+	server, err := findPrivateServer(ctx, api, data.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	// server is completely missing
+	if server == nil {
+		if data.IsNewResource() {
+			return diag.Errorf("private server not found: %s", data.Id())
+		}
+		data.SetId("")
+		return diags
+	}
+
+	current, err := listPrivateServerSettings(ctx, api, data.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	// only the owned keys are refreshed, an import takes ownership of all settings
+	owned := expandSettings(data.Get("settings"))
+	settings := current
+	if len(owned) > 0 {
+		settings = make(map[string]string, len(owned))
+		for key := range owned {
+			if value, ok := current[key]; ok {
+				settings[key] = value
+			}
+		}
+	}
+
+	fields := map[string]interface{}{
+		"ps":       data.Id(),
+		"settings": settings,
+	}
+	for key, value := range fields {
+		if err := data.Set(key, value); err != nil {
+			return diag.FromErr(errors.Wrapf(err, "failed to set field `%s`", key))
+		}
+	}
+
+	return diags
+}
+
+func resourcePrivateServerSettingsUpdate(
+	ctx context.Context, data *schema.ResourceData, config interface{},
+) diag.Diagnostics {
+	api, ok := config.(*cachedDreamhostClient)
+	if !ok {
+		return diag.Errorf("internal error: failed to retrieve dreamhost API client")
+	}
+
+	// settings dropped from the configuration are released, not reset
+	oldValue, newValue := data.GetChange("settings")
+	old, changed := expandSettings(oldValue), expandSettings(newValue)
+	for key, value := range changed {
+		if previous, ok := old[key]; ok && previous == value {
+			delete(changed, key)
+		}
+	}
+
+	if err := setPrivateServerSettings(ctx, api, data.Id(), changed); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return resourcePrivateServerSettingsRead(ctx, data, config)
+}
+
+func resourcePrivateServerSettingsDelete(_ context.Context, _ *schema.ResourceData, _ interface{}) diag.Diagnostics {
+	// the API has no defaults to restore, the settings stay as they are
+	return nil
+}
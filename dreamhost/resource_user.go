@@ -0,0 +1,197 @@
+package dreamhost
+
+import (
+	"context"
+	"net/url"
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/pkg/errors"
+)
+
+func resourceUser() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceUserCreate,
+		ReadContext:   resourceUserRead,
+		UpdateContext: nil,
+		DeleteContext: resourceUserDelete,
+		Schema: map[string]*schema.Schema{
+			"username": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.StringIsNotWhiteSpace,
+				Description:  "the username",
+			},
+			"type": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.StringInSlice(userTypes, false),
+				Description:  "the user type (shell, sftp or ftp)",
+			},
+			"server": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "the server the user lives on, as hostname or short name",
+			},
+			"gecos": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				ForceNew:    true,
+				Description: "the full name of the user",
+			},
+			"password": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				Computed:         true,
+				ForceNew:         true,
+				Sensitive:        true,
+				ValidateFunc:     validation.StringLenBetween(minUserPasswordLength, maxUserPasswordLength),
+				DiffSuppressFunc: suppressImportedUserPasswordDiff,
+				Description:      "the password of the user, generated when not set",
+			},
+
+			// computed values
+			"shell": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "the login shell of the user",
+			},
+			"disk_used_mb": {
+				Type:        schema.TypeFloat,
+				Computed:    true,
+				Description: "the disk usage of the user in megabytes",
+			},
+			"quota_mb": {
+				Type:        schema.TypeFloat,
+				Computed:    true,
+				Description: "the disk quota of the user in megabytes",
+			},
+		},
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+	}
+}
+
+// suppressImportedUserPasswordDiff keeps imported users, whose password the API
+// does not return, from being replaced because of a configured password
+func suppressImportedUserPasswordDiff(_, old, _ string, d *schema.ResourceData) bool {
+	return d.Id() != "" && old == ""
+}
+
+func resourceUserCreate(ctx context.Context, data *schema.ResourceData, config interface{}) diag.Diagnostics {
+	api, ok := config.(*cachedDreamhostClient) // nolint:varnamelen
+	if !ok {
+		return diag.Errorf("internal error: failed to retrieve dreamhost API client")
+	}
+
+	username, _ := data.Get("username").(string)
+	userType, _ := data.Get("type").(string)
+	server, _ := data.Get("server").(string)
+	gecos, _ := data.Get("gecos").(string)
+	password, _ := data.Get("password").(string)
+
+	existing, err := findUser(ctx, api, username)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if existing != nil {
+		return diag.Errorf("user already exists, import it with ID %q", username)
+	}
+
+	if password == "" {
+		if password, err = generatePassword(generatedPasswordLength); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	params := url.Values{
+		"username": {username},
+		"type":     {userType},
+		"server":   {server},
+		"gecos":    {gecos},
+		"password": {password},
+	}
+	if err := api.CallCommand(ctx, addUserCmd, params, nil); err != nil {
+		return diag.FromErr(err)
+	}
+
+	data.SetId(username)
+	if err := data.Set("password", password); err != nil {
+		return diag.FromErr(errors.Wrap(err, "failed to set field `password`"))
+	}
+
+	return resourceUserRead(ctx, data, config)
+}
+
+func resourceUserRead(ctx context.Context, data *schema.ResourceData, config interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	api, ok := config.(*cachedDreamhostClient)
+	if !ok {
+		return diag.Errorf("internal error: failed to retrieve dreamhost API client")
+	}
+
+	user, err := findUser(ctx, api, data.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	// user is completely missing
+	if user == nil {
+		if data.IsNewResource() {
+			return diag.Errorf("user not found: %s", data.Id())
+		}
+		data.SetId("")
+		return diags
+	}
+
+	// keep the configured short server name when it refers to the listed server
+	server, _ := data.Get("server").(string)
+	if server == "" || !matchesServer(user.Home, server) {
+		server = user.Home
+	}
+	diskUsed, _ := strconv.ParseFloat(user.DiskUsedMB, 64)
+	quota, _ := strconv.ParseFloat(user.QuotaMB, 64)
+
+	fields := map[string]interface{}{
+		"username":     user.Username,
+		"type":         user.Type,
+		"server":       server,
+		"gecos":        user.Gecos,
+		"shell":        user.Shell,
+		"disk_used_mb": diskUsed,
+		"quota_mb":     quota,
+	}
+	for key, value := range fields {
+		if err := data.Set(key, value); err != nil {
+			return diag.FromErr(errors.Wrapf(err, "failed to set field `%s`", key))
+		}
+	}
+
+	return diags
+}
+
+func resourceUserDelete(ctx context.Context, data *schema.ResourceData, config interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	api, ok := config.(*cachedDreamhostClient)
+	if !ok {
+		return diag.Errorf("internal error: failed to retrieve dreamhost API client")
+	}
+
+	userType, _ := data.Get("type").(string)
+	params := url.Values{"username": {data.Id()}, "type": {userType}}
+	// a user that is already gone is as good as removed
+	if err := api.CallCommand(ctx, removeUserCmd, params, nil); err != nil && !isCommandError(err, userNotFoundCode) {
+		return diag.FromErr(err)
+	}
+
+	return diags
+}
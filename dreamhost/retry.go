@@ -8,6 +8,7 @@ import (
 	dreamhostapi "github.com/adamantal/go-dreamhost/api"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/pkg/errors"
+	"golang.org/x/net/dns/dnsmessage"
 )
 
 const (
@@ -44,7 +45,7 @@ func isRetryableError(err error) bool {
 	errMsg := err.Error()
 
 	// API rate limiting
-	if contains(errMsg, "rate limit") || contains(errMsg, "too many requests") {
+	if contains(errMsg, "rate limit") || contains(errMsg, "too many requests") || contains(errMsg, "slow_down_bucko") {
 		return true
 	}
 
@@ -146,3 +147,40 @@ func dnsRecordDeletionStateRefreshFunc(ctx context.Context, client *cachedDreamh
 	}
 }
 
+// waitForTXTPropagation waits until every nameserver serves a TXT record with the given value
+func waitForTXTPropagation(ctx context.Context, nameservers []string, name, value string, timeout time.Duration) error {
+	stateConf := &resource.StateChangeConf{
+		Pending:    []string{"propagating"},
+		Target:     []string{"propagated"},
+		Refresh:    txtPropagationStateRefreshFunc(ctx, nameservers, name, value),
+		Timeout:    timeout,
+		MinTimeout: retryMinDelay,
+	}
+
+	_, err := stateConf.WaitForStateContext(ctx)
+	if err != nil {
+		return errors.Wrap(err, "error waiting for DNS record propagation")
+	}
+
+	return nil
+}
+
+// txtPropagationStateRefreshFunc returns a function that checks which nameservers serve a TXT record.
+// Failing queries count as not yet propagated, a nameserver may be briefly unreachable.
+func txtPropagationStateRefreshFunc(ctx context.Context, nameservers []string, name, value string) resource.StateRefreshFunc {
+	return func() (interface{}, string, error) {
+		var pending []string
+		for _, nameserver := range nameservers {
+			resp, err := queryDNS(ctx, nameserver, dnsNetworkUDP, name, dnsmessage.TypeTXT, false)
+			if err != nil || !containsString(txtAnswers(resp), value) {
+				pending = append(pending, nameserver)
+			}
+		}
+
+		if len(pending) > 0 {
+			return pending, "propagating", nil
+		}
+
+		return nameservers, "propagated", nil
+	}
+}
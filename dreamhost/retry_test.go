@@ -107,6 +107,7 @@ func TestIsRetryableError(t *testing.T) {
 		{"nil_error", nil, false},
 		{"rate_limit", fmt.Errorf("rate limit exceeded"), true},
 		{"too_many_requests", fmt.Errorf("too many requests"), true},
+		{"dreamhost_rate_limit", fmt.Errorf("domain-list_domains failed - response: slow_down_bucko"), true},
 		{"timeout", fmt.Errorf("request timeout"), true},
 		{"connection_refused", fmt.Errorf("connection refused"), true},
 		{"service_unavailable", fmt.Errorf("service unavailable"), true},
@@ -438,4 +439,53 @@ func TestDNSRecordDeletionStateRefreshFunc(t *testing.T) {
 		assert.Equal(t, "deleted", state)
 		assert.Nil(t, result)
 	})
-}
\ No newline at end of file
+}
+
+func TestTXTPropagationStateRefreshFunc(t *testing.T) {
+	t.Parallel()
+
+	served := startTestDNSServer(t)
+	served.AddTXT("_acme-challenge.example.com", "token")
+	lagging := startTestDNSServer(t)
+
+	t.Run("pending_until_every_nameserver_serves_the_value", func(t *testing.T) {
+		t.Parallel()
+
+		refreshFunc := txtPropagationStateRefreshFunc(context.Background(),
+			[]string{served.Addr, lagging.Addr}, "_acme-challenge.example.com", "token")
+		result, state, err := refreshFunc()
+
+		require.NoError(t, err)
+		assert.Equal(t, "propagating", state)
+		assert.Equal(t, []string{lagging.Addr}, result)
+	})
+
+	t.Run("wrong_value_is_pending", func(t *testing.T) {
+		t.Parallel()
+
+		refreshFunc := txtPropagationStateRefreshFunc(context.Background(),
+			[]string{served.Addr}, "_acme-challenge.example.com", "other-token")
+		_, state, err := refreshFunc()
+
+		require.NoError(t, err)
+		assert.Equal(t, "propagating", state)
+	})
+}
+
+func TestWaitForTXTPropagation(t *testing.T) {
+	t.Parallel()
+
+	server := startTestDNSServer(t)
+	go func() {
+		time.Sleep(500 * time.Millisecond)
+		server.AddTXT("_acme-challenge.example.com", "token")
+	}()
+
+	err := waitForTXTPropagation(context.Background(), []string{server.Addr},
+		"_acme-challenge.example.com", "token", 10*time.Second)
+	assert.NoError(t, err)
+
+	err = waitForTXTPropagation(context.Background(), []string{server.Addr},
+		"_acme-challenge.example.com", "never-served", 2*time.Second)
+	assert.Error(t, err)
+}
@@ -0,0 +1,107 @@
+package dreamhost
+
+import (
+	"fmt"
+	"net"
+	"strings"
+
+	dreamhostapi "github.com/adamantal/go-dreamhost/api"
+)
+
+const (
+	ipv4ReverseSuffix = "in-addr.arpa"
+	ipv6ReverseSuffix = "ip6.arpa"
+
+	hexDigits = "0123456789abcdef"
+)
+
+// reverseDNSName returns the in-addr.arpa or nibble-reversed ip6.arpa name of an IP address
+func reverseDNSName(ip net.IP) (string, error) {
+	if v4 := ip.To4(); v4 != nil {
+		return fmt.Sprintf("%d.%d.%d.%d.%s", v4[3], v4[2], v4[1], v4[0], ipv4ReverseSuffix), nil
+	}
+
+	v6 := ip.To16()
+	if v6 == nil {
+		return "", fmt.Errorf("invalid IP address: %v", ip)
+	}
+	labels := make([]string, 0, 2*net.IPv6len+1)
+	for i := len(v6) - 1; i >= 0; i-- {
+		labels = append(labels, string(hexDigits[v6[i]&0x0f]), string(hexDigits[v6[i]>>4]))
+	}
+	labels = append(labels, ipv6ReverseSuffix)
+	return strings.Join(labels, "."), nil
+}
+
+// ipFromReverseDNSName is the inverse of reverseDNSName
+func ipFromReverseDNSName(name string) (net.IP, error) {
+	name = strings.ToLower(strings.TrimSuffix(name, "."))
+
+	switch {
+	case strings.HasSuffix(name, "."+ipv4ReverseSuffix):
+		octets := strings.Split(strings.TrimSuffix(name, "."+ipv4ReverseSuffix), ".")
+		if len(octets) == net.IPv4len {
+			ip := net.ParseIP(strings.Join([]string{octets[3], octets[2], octets[1], octets[0]}, "."))
+			if ip != nil {
+				return ip, nil
+			}
+		}
+	case strings.HasSuffix(name, "."+ipv6ReverseSuffix):
+		nibbles := strings.Split(strings.TrimSuffix(name, "."+ipv6ReverseSuffix), ".")
+		if len(nibbles) == 2*net.IPv6len {
+			ip := make(net.IP, net.IPv6len)
+			valid := true
+			for i, nibble := range nibbles {
+				digit := strings.Index(hexDigits, nibble)
+				if len(nibble) != 1 || digit < 0 {
+					valid = false
+					break
+				}
+				pos := len(ip) - 1 - i/2
+				if i%2 == 0 {
+					ip[pos] |= byte(digit)
+				} else {
+					ip[pos] |= byte(digit) << 4
+				}
+			}
+			if valid {
+				return ip, nil
+			}
+		}
+	}
+
+	return nil, fmt.Errorf("%s is not a reverse DNS name of a single address", name)
+}
+
+// findReverseZone returns the most specific zone of the account containing the reverse name
+func findReverseZone(records []dreamhostapi.DNSRecord, reverseName string) string {
+	reverseName = strings.ToLower(reverseName)
+
+	var best string
+	for _, record := range records {
+		zone := strings.ToLower(strings.TrimSuffix(record.Zone, "."))
+		if zone == "" || len(zone) <= len(best) {
+			continue
+		}
+		if reverseName == zone || strings.HasSuffix(reverseName, "."+zone) {
+			best = zone
+		}
+	}
+	return best
+}
+
+// hasForwardRecord checks whether the listing has an A or AAAA record pointing hostname at ip
+func hasForwardRecord(records []dreamhostapi.DNSRecord, hostname string, ip net.IP) bool {
+	recordType := dreamhostapi.AAAARecordType
+	if ip.To4() != nil {
+		recordType = dreamhostapi.ARecordType
+	}
+	hostname = strings.TrimSuffix(hostname, ".")
+
+	for _, record := range records {
+		if record.Type == recordType && strings.EqualFold(record.Record, hostname) && ip.Equal(net.ParseIP(record.Value)) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,82 @@
+package dreamhost
+
+import (
+	"net"
+	"testing"
+
+	dreamhostapi "github.com/adamantal/go-dreamhost/api"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReverseDNSName(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		ip       string
+		expected string
+	}{
+		{"ipv4", "192.0.2.1", "1.2.0.192.in-addr.arpa"},
+		{"ipv6", "2001:db8::567:89ab",
+			"b.a.9.8.7.6.5.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.8.b.d.0.1.0.0.2.ip6.arpa"},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			name, err := reverseDNSName(net.ParseIP(tt.ip))
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, name)
+
+			ip, err := ipFromReverseDNSName(name + ".")
+			require.NoError(t, err)
+			assert.True(t, net.ParseIP(tt.ip).Equal(ip))
+		})
+	}
+}
+
+func TestIPFromReverseDNSName_Errors(t *testing.T) {
+	t.Parallel()
+
+	for _, name := range []string{
+		"2.0.192.in-addr.arpa",
+		"1.2.0.300.in-addr.arpa",
+		"8.b.d.0.1.0.0.2.ip6.arpa",
+		"example.com",
+	} {
+		_, err := ipFromReverseDNSName(name)
+		assert.Error(t, err, name)
+	}
+}
+
+func TestFindReverseZone(t *testing.T) {
+	t.Parallel()
+
+	records := []dreamhostapi.DNSRecord{
+		{Record: "example.com", Zone: "example.com"},
+		{Record: "0.192.in-addr.arpa", Zone: "0.192.in-addr.arpa"},
+		{Record: "2.0.192.in-addr.arpa", Zone: "2.0.192.in-addr.arpa"},
+	}
+
+	assert.Equal(t, "2.0.192.in-addr.arpa", findReverseZone(records, "1.2.0.192.in-addr.arpa"))
+	assert.Equal(t, "0.192.in-addr.arpa", findReverseZone(records, "1.3.0.192.in-addr.arpa"))
+	assert.Empty(t, findReverseZone(records, "1.2.0.193.in-addr.arpa"))
+	assert.Error(t, checkReverseZone(records, "1.1.10.in-addr.arpa"))
+}
+
+func TestHasForwardRecord(t *testing.T) {
+	t.Parallel()
+
+	records := []dreamhostapi.DNSRecord{
+		{Record: "mail.example.com", Type: dreamhostapi.ARecordType, Value: "192.0.2.1"},
+		{Record: "mail.example.com", Type: dreamhostapi.AAAARecordType, Value: "2001:db8::1"},
+	}
+
+	assert.True(t, hasForwardRecord(records, "mail.example.com.", net.ParseIP("192.0.2.1")))
+	assert.True(t, hasForwardRecord(records, "MAIL.example.com", net.ParseIP("2001:db8:0::1")))
+	assert.False(t, hasForwardRecord(records, "mail.example.com", net.ParseIP("192.0.2.2")))
+	assert.False(t, hasForwardRecord(records, "www.example.com", net.ParseIP("192.0.2.1")))
+}
@@ -0,0 +1,208 @@
+package dreamhost
+
+import (
+	"fmt"
+	"strings"
+
+	dreamhostapi "github.com/adamantal/go-dreamhost/api"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/pkg/errors"
+)
+
+const (
+	spfVersion = "v=spf1"
+
+	// spfMaxDNSLookups is the limit on DNS-querying terms from RFC 7208 section 4.6.4
+	spfMaxDNSLookups = 10
+
+	// maxTXTStringLength is the longest single character-string a TXT record can hold
+	maxTXTStringLength = 255
+)
+
+// spfRecord is the structured form of an SPF TXT record value
+type spfRecord struct {
+	A        []string
+	MX       []string
+	IP4      []string
+	IP6      []string
+	Include  []string
+	Redirect string
+	All      string
+}
+
+// String renders the SPF record as a TXT record value
+func (s spfRecord) String() string {
+	terms := []string{spfVersion}
+	for _, domain := range s.A {
+		terms = append(terms, spfDomainTerm("a", domain))
+	}
+	for _, domain := range s.MX {
+		terms = append(terms, spfDomainTerm("mx", domain))
+	}
+	for _, network := range s.IP4 {
+		terms = append(terms, "ip4:"+network)
+	}
+	for _, network := range s.IP6 {
+		terms = append(terms, "ip6:"+network)
+	}
+	for _, domain := range s.Include {
+		terms = append(terms, "include:"+domain)
+	}
+	if s.Redirect != "" {
+		terms = append(terms, "redirect="+s.Redirect)
+	}
+	if s.All != "" {
+		terms = append(terms, s.All)
+	}
+	return strings.Join(terms, " ")
+}
+
+// spfDomainTerm renders an `a` or `mx` mechanism, where "@" stands for the record's own name
+func spfDomainTerm(mechanism, domain string) string {
+	if domain == "@" {
+		return mechanism
+	}
+	return mechanism + ":" + domain
+}
+
+// isSPFValue checks whether a TXT record value is an SPF record
+func isSPFValue(value string) bool {
+	value = strings.Trim(value, `"`)
+	return value == spfVersion || strings.HasPrefix(value, spfVersion+" ")
+}
+
+// parseSPFRecord parses a TXT record value into its structured form. Only the
+// terms the dreamhost_dns_spf_record resource can manage are accepted.
+func parseSPFRecord(value string) (*spfRecord, error) {
+	if !isSPFValue(value) {
+		return nil, fmt.Errorf("not an SPF record: %q", value)
+	}
+
+	spf := &spfRecord{}
+	terms := strings.Fields(strings.Trim(value, `"`))
+	for _, term := range terms[1:] {
+		term = strings.TrimPrefix(term, "+")
+		lower := strings.ToLower(term)
+
+		switch {
+		case lower == "a" || lower == "mx":
+			spf.appendDomain(lower, "@")
+		case strings.HasPrefix(lower, "a:") || strings.HasPrefix(lower, "mx:"):
+			parts := strings.SplitN(term, ":", 2)
+			spf.appendDomain(strings.ToLower(parts[0]), parts[1])
+		case strings.HasPrefix(lower, "ip4:"):
+			spf.IP4 = append(spf.IP4, term[len("ip4:"):])
+		case strings.HasPrefix(lower, "ip6:"):
+			spf.IP6 = append(spf.IP6, term[len("ip6:"):])
+		case strings.HasPrefix(lower, "include:"):
+			spf.Include = append(spf.Include, term[len("include:"):])
+		case strings.HasPrefix(lower, "redirect="):
+			spf.Redirect = term[len("redirect="):]
+		case lower == "all" || lower == "-all" || lower == "~all" || lower == "?all":
+			spf.All = lower
+			if lower == "all" {
+				spf.All = "+all"
+			}
+		default:
+			return nil, fmt.Errorf("unsupported SPF term %q in %q", term, value)
+		}
+	}
+
+	return spf, nil
+}
+
+func (s *spfRecord) appendDomain(mechanism, domain string) {
+	if mechanism == "a" {
+		s.A = append(s.A, domain)
+	} else {
+		s.MX = append(s.MX, domain)
+	}
+}
+
+// countSPFLookups counts the DNS-querying terms of an SPF record, following
+// include and redirect targets that are published in the account's own zones.
+// The returned flag reports whether every target could be resolved from the
+// listing; if not, the count is a lower bound.
+func countSPFLookups(spf *spfRecord, records []dreamhostapi.DNSRecord) (int, bool) {
+	return countSPFLookupsVisited(spf, records, map[string]bool{})
+}
+
+func countSPFLookupsVisited(spf *spfRecord, records []dreamhostapi.DNSRecord, visited map[string]bool) (int, bool) {
+	count := len(spf.A) + len(spf.MX)
+	exact := true
+
+	targets := spf.Include
+	if spf.Redirect != "" {
+		targets = append(append([]string{}, targets...), spf.Redirect)
+	}
+	for _, target := range targets {
+		count++
+
+		name := strings.ToLower(strings.TrimSuffix(target, "."))
+		if visited[name] {
+			// a loop is a permanent error in SPF evaluation, stop following it
+			continue
+		}
+
+		nested := findSPFRecords(records, name, "")
+		if len(nested) != 1 {
+			exact = false
+			continue
+		}
+		nestedSPF, err := parseSPFRecord(nested[0].Value)
+		if err != nil {
+			exact = false
+			continue
+		}
+
+		// visited only holds the current include chain, a record reached through
+		// several includes is evaluated, and counted, every time
+		visited[name] = true
+		nestedCount, nestedExact := countSPFLookupsVisited(nestedSPF, records, visited)
+		delete(visited, name)
+
+		count += nestedCount
+		exact = exact && nestedExact
+	}
+
+	return count, exact
+}
+
+// findSPFRecords returns the SPF TXT records published at name, skipping the
+// record with the given resource ID
+func findSPFRecords(records []dreamhostapi.DNSRecord, name, skipID string) []dreamhostapi.DNSRecord {
+	return findTXTRecords(records, name, skipID, isSPFValue)
+}
+
+// checkDuplicateSPF returns an error if another SPF record is already published at name
+func checkDuplicateSPF(records []dreamhostapi.DNSRecord, name, skipID string) error {
+	if existing := findSPFRecords(records, name, skipID); len(existing) > 0 {
+		return errors.Errorf(
+			"an SPF record already exists for %s (%q); a name can only publish a single SPF record",
+			name, existing[0].Value,
+		)
+	}
+	return nil
+}
+
+// spfWarnings reports SPF values that receivers are likely to reject
+func spfWarnings(value string, lookups int, exact bool) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	diags = append(diags, txtLengthWarnings("SPF", value)...)
+
+	if lookups > spfMaxDNSLookups {
+		detail := fmt.Sprintf("The SPF record requires %d DNS lookups", lookups)
+		if !exact {
+			detail = fmt.Sprintf("The SPF record requires at least %d DNS lookups", lookups)
+		}
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Warning,
+			Summary:  "SPF record exceeds the DNS lookup limit",
+			Detail: fmt.Sprintf("%s, more than the limit of %d. Receivers will fail SPF evaluation with a PermError.",
+				detail, spfMaxDNSLookups),
+		})
+	}
+
+	return diags
+}
@@ -0,0 +1,161 @@
+package dreamhost
+
+import (
+	"strings"
+	"testing"
+
+	dreamhostapi "github.com/adamantal/go-dreamhost/api"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSPFRecord_String(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		spf      spfRecord
+		expected string
+	}{
+		{"empty", spfRecord{}, "v=spf1"},
+		{
+			"all_mechanisms",
+			spfRecord{
+				A:       []string{"@", "web.example.com"},
+				MX:      []string{"@"},
+				IP4:     []string{"192.0.2.0/24"},
+				IP6:     []string{"2001:db8::/32"},
+				Include: []string{"_spf.example.net"},
+				All:     "-all",
+			},
+			"v=spf1 a a:web.example.com mx ip4:192.0.2.0/24 ip6:2001:db8::/32 include:_spf.example.net -all",
+		},
+		{"redirect", spfRecord{Redirect: "_spf.example.com"}, "v=spf1 redirect=_spf.example.com"},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, tt.expected, tt.spf.String())
+		})
+	}
+}
+
+func TestParseSPFRecord(t *testing.T) {
+	t.Parallel()
+
+	t.Run("round_trip", func(t *testing.T) {
+		t.Parallel()
+
+		value := "v=spf1 a mx:mail.example.com ip4:192.0.2.1 ip6:2001:db8::1 include:_spf.google.com ~all"
+		spf, err := parseSPFRecord(value)
+		require.NoError(t, err)
+
+		assert.Equal(t, []string{"@"}, spf.A)
+		assert.Equal(t, []string{"mail.example.com"}, spf.MX)
+		assert.Equal(t, []string{"_spf.google.com"}, spf.Include)
+		assert.Equal(t, "~all", spf.All)
+		assert.Equal(t, value, spf.String())
+	})
+
+	t.Run("quoted_and_qualified", func(t *testing.T) {
+		t.Parallel()
+
+		spf, err := parseSPFRecord(`"v=spf1 +a +all"`)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"@"}, spf.A)
+		assert.Equal(t, "+all", spf.All)
+	})
+
+	t.Run("not_spf", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := parseSPFRecord("v=DMARC1; p=none")
+		assert.Error(t, err)
+	})
+
+	t.Run("unsupported_term", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := parseSPFRecord("v=spf1 exists:%{i}.example.com -all")
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "unsupported SPF term")
+	})
+}
+
+func TestCountSPFLookups(t *testing.T) {
+	t.Parallel()
+
+	records := []dreamhostapi.DNSRecord{
+		{Record: "_spf.example.com", Type: dreamhostapi.TXTRecordType, Value: "v=spf1 a mx include:_spf2.example.com ~all"},
+		{Record: "_spf2.example.com", Type: dreamhostapi.TXTRecordType, Value: "v=spf1 ip4:192.0.2.1 -all"},
+		{Record: "_loop.example.com", Type: dreamhostapi.TXTRecordType, Value: "v=spf1 include:_loop.example.com -all"},
+		{Record: "_mail.example.com", Type: dreamhostapi.TXTRecordType, Value: "v=spf1 include:_spf.example.com -all"},
+		{Record: "_web.example.com", Type: dreamhostapi.TXTRecordType, Value: "v=spf1 a include:_spf.example.com -all"},
+	}
+
+	t.Run("own_zones_are_followed", func(t *testing.T) {
+		t.Parallel()
+
+		count, exact := countSPFLookups(&spfRecord{A: []string{"@"}, Include: []string{"_spf.example.com"}}, records)
+		assert.Equal(t, 5, count)
+		assert.True(t, exact)
+	})
+
+	t.Run("foreign_include_is_a_lower_bound", func(t *testing.T) {
+		t.Parallel()
+
+		count, exact := countSPFLookups(&spfRecord{Include: []string{"_spf.google.com"}}, records)
+		assert.Equal(t, 1, count)
+		assert.False(t, exact)
+	})
+
+	t.Run("shared_include_is_counted_per_evaluation", func(t *testing.T) {
+		t.Parallel()
+
+		// 2 includes, _mail: 1 + 3 for _spf, _web: 2 + 3 for _spf again
+		count, exact := countSPFLookups(&spfRecord{Include: []string{"_mail.example.com", "_web.example.com"}}, records)
+		assert.Equal(t, 11, count)
+		assert.True(t, exact)
+	})
+
+	t.Run("loops_terminate", func(t *testing.T) {
+		t.Parallel()
+
+		count, _ := countSPFLookups(&spfRecord{Redirect: "_loop.example.com"}, records)
+		assert.Equal(t, 2, count)
+	})
+}
+
+func TestCheckDuplicateSPF(t *testing.T) {
+	t.Parallel()
+
+	existing := dreamhostapi.DNSRecord{Record: "example.com", Type: dreamhostapi.TXTRecordType, Value: "v=spf1 -all"}
+	records := []dreamhostapi.DNSRecord{
+		existing,
+		{Record: "example.com", Type: dreamhostapi.TXTRecordType, Value: "google-site-verification=abc"},
+	}
+
+	assert.Error(t, checkDuplicateSPF(records, "example.com", ""))
+	assert.Error(t, checkDuplicateSPF(records, "EXAMPLE.com", ""))
+	assert.NoError(t, checkDuplicateSPF(records, "www.example.com", ""))
+
+	id := recordInputToID(dreamhostapi.DNSRecordInput{Record: existing.Record, Type: existing.Type, Value: existing.Value})
+	assert.NoError(t, checkDuplicateSPF(records, "example.com", id))
+}
+
+func TestSPFWarnings(t *testing.T) {
+	t.Parallel()
+
+	assert.Empty(t, spfWarnings("v=spf1 -all", 3, true))
+
+	diags := spfWarnings("v=spf1 "+strings.Repeat("a", maxTXTStringLength), spfMaxDNSLookups+1, false)
+	require.Len(t, diags, 2)
+	for _, d := range diags {
+		assert.Equal(t, diag.Warning, d.Severity)
+	}
+	assert.Contains(t, diags[1].Detail, "at least 11")
+}
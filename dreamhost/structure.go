@@ -0,0 +1,40 @@
+package dreamhost
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+
+	"github.com/pkg/errors"
+)
+
+// expandStringList converts a Terraform list of strings into a string slice
+func expandStringList(list []interface{}) []string {
+	result := make([]string, 0, len(list))
+	for _, v := range list {
+		s, _ := v.(string)
+		result = append(result, s)
+	}
+	return result
+}
+
+// containsString reports whether list contains s
+func containsString(list []string, s string) bool {
+	for _, v := range list {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
+
+// hashID hashes the query and its result into a stable data source ID
+func hashID(query map[string]interface{}) (string, error) {
+	// encoding/json sorts map keys, so equal queries produce equal documents
+	document, err := json.Marshal(query)
+	if err != nil {
+		return "", errors.Wrap(err, "failed to compute data source ID")
+	}
+	sum := sha256.Sum256(document)
+	return hex.EncodeToString(sum[:]), nil
+}
@@ -0,0 +1,96 @@
+package dreamhost
+
+import (
+	"context"
+	"crypto/rand"
+	"math/big"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	addUserCmd    = "user-add_user"
+	removeUserCmd = "user-remove_user"
+	// userNotFoundCode is returned when removing a user that does not exist
+	userNotFoundCode = "no_such_user"
+	// listUsersNoPwCmd is used instead of user-list_users, which also returns the passwords
+	listUsersNoPwCmd = "user-list_users_no_pw"
+
+	generatedPasswordLength = 20
+	minUserPasswordLength   = 8
+	maxUserPasswordLength   = 64
+	passwordCharacters      = "abcdefghijkmnopqrstuvwxyzABCDEFGHJKLMNPQRSTUVWXYZ23456789"
+)
+
+// userTypes are the user types user-add_user accepts
+// nolint:gochecknoglobals
+var userTypes = []string{"shell", "sftp", "ftp"}
+
+// dreamhostUser is a user as returned by user-list_users_no_pw
+type dreamhostUser struct {
+	AccountID  string `json:"account_id"`
+	Username   string `json:"username"`
+	Type       string `json:"type"`
+	Shell      string `json:"shell"`
+	Home       string `json:"home"`
+	Gecos      string `json:"gecos"`
+	DiskUsedMB string `json:"disk_used_mb"`
+	QuotaMB    string `json:"quota_mb"`
+}
+
+// listUsers returns the users of the account without their passwords
+func listUsers(ctx context.Context, api *cachedDreamhostClient) ([]dreamhostUser, error) {
+	var users []dreamhostUser
+	if err := api.CallCachedCommand(ctx, listUsersNoPwCmd, nil, &users); err != nil {
+		return nil, errors.Wrap(err, "failed to list users")
+	}
+	return users, nil
+}
+
+// findUser looks the username up in the cached listing
+func findUser(ctx context.Context, api *cachedDreamhostClient, username string) (*dreamhostUser, error) {
+	users, err := listUsers(ctx, api)
+	if err != nil {
+		return nil, err
+	}
+	for i := range users {
+		if users[i].Username == username {
+			return &users[i], nil
+		}
+	}
+	return nil, nil
+}
+
+// filterUsers returns the users matching all non-empty criteria, sorted by username
+func filterUsers(users []dreamhostUser, userType, server string) []dreamhostUser {
+	filtered := []dreamhostUser{}
+	for _, user := range users {
+		if userType != "" && user.Type != userType {
+			continue
+		}
+		if server != "" && !matchesServer(user.Home, server) {
+			continue
+		}
+		filtered = append(filtered, user)
+	}
+	sort.SliceStable(filtered, func(i, j int) bool {
+		return filtered[i].Username < filtered[j].Username
+	})
+	return filtered
+}
+
+// generatePassword returns a random password without easily confused characters
+func generatePassword(length int) (string, error) {
+	var password strings.Builder
+	limit := big.NewInt(int64(len(passwordCharacters)))
+	for i := 0; i < length; i++ {
+		n, err := rand.Int(rand.Reader, limit)
+		if err != nil {
+			return "", errors.Wrap(err, "failed to generate password")
+		}
+		password.WriteByte(passwordCharacters[n.Int64()])
+	}
+	return password.String(), nil
+}
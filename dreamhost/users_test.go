@@ -0,0 +1,198 @@
+package dreamhost
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testUsersResponse = `[
+	{"account_id":"1","username":"deploy","type":"shell","shell":"/bin/bash","home":"spork.dreamhost.com",
+	 "gecos":"Deploy Bot","disk_used_mb":"120.5","quota_mb":"0"},
+	{"account_id":"1","username":"contractor","type":"sftp","shell":"/usr/bin/rssh","home":"knife.dreamhost.com",
+	 "gecos":"Jane Contractor","disk_used_mb":"3","quota_mb":"500"},
+	{"account_id":"1","username":"backup","type":"ftp","shell":"","home":"spork.dreamhost.com",
+	 "gecos":"","disk_used_mb":"","quota_mb":""}
+]`
+
+func TestGeneratePassword(t *testing.T) {
+	t.Parallel()
+
+	first, err := generatePassword(generatedPasswordLength)
+	require.NoError(t, err)
+	second, err := generatePassword(generatedPasswordLength)
+	require.NoError(t, err)
+
+	assert.Len(t, first, generatedPasswordLength)
+	assert.NotEqual(t, first, second)
+	for _, ch := range first {
+		assert.True(t, strings.ContainsRune(passwordCharacters, ch), "unexpected character %q", ch)
+	}
+}
+
+func TestResourceUser(t *testing.T) {
+	t.Parallel()
+
+	caller := newFakeCommandCaller()
+	caller.Respond(listUsersNoPwCmd, testUsersResponse)
+	caller.Respond(removeUserCmd, `"success"`)
+	client := newFakeCommandClient(caller)
+
+	d := schema.TestResourceDataRaw(t, resourceUser().Schema, map[string]interface{}{"server": "knife"})
+	d.SetId("contractor")
+	diags := resourceUserRead(context.Background(), d, client)
+	require.False(t, diags.HasError(), "%v", diags)
+	assert.Equal(t, "sftp", d.Get("type"))
+	assert.Equal(t, "knife", d.Get("server"))
+	assert.Equal(t, "Jane Contractor", d.Get("gecos"))
+	assert.Equal(t, 500.0, d.Get("quota_mb"))
+
+	// imported users get the listed server
+	imported := schema.TestResourceDataRaw(t, resourceUser().Schema, map[string]interface{}{})
+	imported.SetId("deploy")
+	diags = resourceUserRead(context.Background(), imported, client)
+	require.False(t, diags.HasError(), "%v", diags)
+	assert.Equal(t, "spork.dreamhost.com", imported.Get("server"))
+	assert.Equal(t, 120.5, imported.Get("disk_used_mb"))
+
+	diags = resourceUserDelete(context.Background(), d, client)
+	require.False(t, diags.HasError(), "%v", diags)
+
+	// existing users must be imported rather than added again
+	existing := schema.TestResourceDataRaw(t, resourceUser().Schema, map[string]interface{}{
+		"username": "backup", "type": "ftp", "server": "spork",
+	})
+	diags = resourceUserCreate(context.Background(), existing, client)
+	require.True(t, diags.HasError())
+	assert.Contains(t, diags[0].Summary, `import it with ID "backup"`)
+
+	// users removed outside of Terraform are dropped from state
+	missing := schema.TestResourceDataRaw(t, resourceUser().Schema, map[string]interface{}{})
+	missing.SetId("gone")
+	diags = resourceUserRead(context.Background(), missing, client)
+	require.False(t, diags.HasError(), "%v", diags)
+	assert.Empty(t, missing.Id())
+
+	calls := caller.Calls()
+	assert.Contains(t, calls, removeUserCmd+"?type=sftp&username=contractor")
+	for _, call := range calls {
+		assert.False(t, strings.HasPrefix(call, "user-list_users?"), "passwords must never be listed")
+	}
+}
+
+func TestResourceUserCreate(t *testing.T) {
+	t.Parallel()
+
+	caller := newFakeCommandCaller()
+	caller.Respond(listUsersNoPwCmd, `[]`)
+	caller.Respond(addUserCmd, `"success"`)
+	client := newFakeCommandClient(caller)
+
+	d := schema.TestResourceDataRaw(t, resourceUser().Schema, map[string]interface{}{
+		"username": "contractor", "type": "sftp", "server": "knife", "gecos": "Jane Contractor",
+	})
+	// the fake listing never shows the new user, so the read after creation drops it again
+	_ = resourceUserCreate(context.Background(), d, client)
+
+	var added string
+	for _, call := range caller.Calls() {
+		if strings.HasPrefix(call, addUserCmd+"?") {
+			added = call
+		}
+	}
+	require.NotEmpty(t, added)
+	assert.Contains(t, added, "gecos=Jane+Contractor")
+	assert.Contains(t, added, "server=knife")
+	assert.Contains(t, added, "type=sftp")
+	assert.Regexp(t, "password=[a-zA-Z0-9]{20}&", added)
+}
+
+func TestResourceUserCreateKeepsPasswordOutOfErrors(t *testing.T) {
+	t.Parallel()
+
+	// listing users works, but the connection drops while adding the user
+	transport := roundTripperFunc(func(r *http.Request) (*http.Response, error) {
+		if err := r.ParseForm(); err != nil {
+			return nil, err
+		}
+		if r.PostForm.Get("cmd") == listUsersNoPwCmd {
+			return &http.Response{
+				StatusCode: http.StatusOK,
+				Body:       io.NopCloser(strings.NewReader(`{"result":"success","data":[]}`)),
+			}, nil
+		}
+		return nil, fmt.Errorf("connection reset by peer")
+	})
+	client := newDreamhostClient(NewMockDreamhostClient())
+	client.commands = newCommandClient("secret-key", &http.Client{Transport: transport})
+
+	d := schema.TestResourceDataRaw(t, resourceUser().Schema, map[string]interface{}{
+		"username": "contractor", "type": "sftp", "server": "knife", "password": "configured-secret",
+	})
+	diags := resourceUserCreate(context.Background(), d, client)
+	require.True(t, diags.HasError())
+	for _, diagnostic := range diags {
+		for _, text := range []string{diagnostic.Summary, diagnostic.Detail} {
+			assert.NotContains(t, text, "password=")
+			assert.NotContains(t, text, "configured-secret")
+			assert.NotContains(t, text, "secret-key")
+		}
+	}
+}
+
+func TestResourceUserImportedPassword(t *testing.T) {
+	t.Parallel()
+
+	state := &terraform.InstanceState{
+		ID: "contractor",
+		Attributes: map[string]string{
+			"id": "contractor", "username": "contractor", "type": "sftp", "server": "knife", "gecos": "Jane Contractor",
+		},
+	}
+	config := terraform.NewResourceConfigRaw(map[string]interface{}{
+		"username": "contractor", "type": "sftp", "server": "knife", "password": "configured-secret",
+	})
+
+	diff, err := resourceUser().Diff(context.Background(), state, config, nil)
+	require.NoError(t, err)
+	assert.True(t, diff == nil || diff.Attributes["password"] == nil, "%v", diff)
+}
+
+func TestDataSourceUsersRead(t *testing.T) {
+	t.Parallel()
+
+	caller := newFakeCommandCaller()
+	caller.Respond(listUsersNoPwCmd, testUsersResponse)
+	client := newFakeCommandClient(caller)
+
+	d := schema.TestResourceDataRaw(t, dataSourceUsers().Schema, map[string]interface{}{"server": "spork"})
+	diags := dataSourceUsersRead(context.Background(), d, client)
+	require.False(t, diags.HasError(), "%v", diags)
+	assert.Equal(t, []interface{}{"backup", "deploy"}, d.Get("usernames"))
+	assert.Equal(t, "/bin/bash", d.Get("users.1.shell"))
+	assert.Equal(t, 120.5, d.Get("users.1.disk_used_mb"))
+	assert.Equal(t, 0.0, d.Get("users.0.quota_mb"))
+
+	// the ID does not depend on the order of the listing or on disk usage
+	reordered := newFakeCommandCaller()
+	reordered.Respond(listUsersNoPwCmd, `[
+		{"username":"deploy","type":"shell","home":"spork.dreamhost.com","disk_used_mb":"130"},
+		{"username":"backup","type":"ftp","home":"spork.dreamhost.com"}
+	]`)
+	again := schema.TestResourceDataRaw(t, dataSourceUsers().Schema, map[string]interface{}{"server": "spork"})
+	diags = dataSourceUsersRead(context.Background(), again, newFakeCommandClient(reordered))
+	require.False(t, diags.HasError(), "%v", diags)
+	assert.Equal(t, d.Id(), again.Id())
+
+	sftp := filterUsers([]dreamhostUser{{Username: "b", Type: "sftp"}, {Username: "a", Type: "shell"}}, "sftp", "")
+	require.Len(t, sftp, 1)
+	assert.Equal(t, "b", sftp[0].Username)
+}
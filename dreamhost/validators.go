@@ -57,6 +57,76 @@ func ValidateIPv6Address() schema.SchemaValidateFunc {
 	}
 }
 
+// ValidateIPv4Network validates an IPv4 address or CIDR block
+func ValidateIPv4Network() schema.SchemaValidateFunc {
+	return func(i interface{}, k string) (warnings []string, errors []error) {
+		v, ok := i.(string)
+		if !ok {
+			errors = append(errors, fmt.Errorf("expected type of %s to be string", k))
+			return warnings, errors
+		}
+
+		if !isIPNetwork(v, net.IPv4len) {
+			errors = append(errors, fmt.Errorf("%s is not a valid IPv4 address or CIDR block", v))
+		}
+
+		return warnings, errors
+	}
+}
+
+// ValidateIPv6Network validates an IPv6 address or CIDR block
+func ValidateIPv6Network() schema.SchemaValidateFunc {
+	return func(i interface{}, k string) (warnings []string, errors []error) {
+		v, ok := i.(string)
+		if !ok {
+			errors = append(errors, fmt.Errorf("expected type of %s to be string", k))
+			return warnings, errors
+		}
+
+		if !isIPNetwork(v, net.IPv6len) {
+			errors = append(errors, fmt.Errorf("%s is not a valid IPv6 address or CIDR block", v))
+		}
+
+		return warnings, errors
+	}
+}
+
+// ValidateDomainName validates a domain name, allowing the underscore-prefixed
+// labels used by SPF, DKIM, DMARC and SRV names
+func ValidateDomainName() schema.SchemaValidateFunc {
+	return func(i interface{}, k string) (warnings []string, errors []error) {
+		v, ok := i.(string)
+		if !ok {
+			errors = append(errors, fmt.Errorf("expected type of %s to be string", k))
+			return warnings, errors
+		}
+
+		if !isValidDomainName(v) {
+			errors = append(errors, fmt.Errorf("%s is not a valid domain name", v))
+		}
+
+		return warnings, errors
+	}
+}
+
+// ValidateEmailAddress validates a mailbox address (local-part@domain)
+func ValidateEmailAddress() schema.SchemaValidateFunc {
+	return func(i interface{}, k string) (warnings []string, errors []error) {
+		v, ok := i.(string)
+		if !ok {
+			errors = append(errors, fmt.Errorf("expected type of %s to be string", k))
+			return warnings, errors
+		}
+
+		at := strings.LastIndex(v, "@")
+		if at < 1 || strings.ContainsAny(v[:at], " \t@") || !isValidHostname(v[at+1:]) {
+			errors = append(errors, fmt.Errorf("%s is not a valid email address", v))
+		}
+
+		return warnings, errors
+	}
+}
+
 // ValidateMXRecord validates an MX record value (priority hostname)
 func ValidateMXRecord() schema.SchemaValidateFunc {
 	return func(i interface{}, k string) (warnings []string, errors []error) {
@@ -145,6 +215,29 @@ func ValidateSRVRecord() schema.SchemaValidateFunc {
 	}
 }
 
+// ValidateNAPTRRecord validates a NAPTR record value
+// (order preference "flags" "service" "regexp" replacement)
+func ValidateNAPTRRecord() schema.SchemaValidateFunc {
+	return func(i interface{}, k string) (warnings []string, errors []error) {
+		v, ok := i.(string)
+		if !ok {
+			errors = append(errors, fmt.Errorf("expected type of %s to be string", k))
+			return warnings, errors
+		}
+
+		naptr, err := parseNAPTRRecord(v)
+		if err != nil {
+			errors = append(errors, err)
+			return warnings, errors
+		}
+		if err := naptr.validate(); err != nil {
+			errors = append(errors, err)
+		}
+
+		return warnings, errors
+	}
+}
+
 // ValidateDNSRecordValue validates the value based on the record type
 func ValidateDNSRecordValue(recordType string) schema.SchemaValidateFunc {
 	return func(i interface{}, k string) (warnings []string, errors []error) {
@@ -169,6 +262,8 @@ func ValidateDNSRecordValue(recordType string) schema.SchemaValidateFunc {
 			return ValidateTXTRecord()(i, k)
 		case "SRV":
 			return ValidateSRVRecord()(i, k)
+		case "NAPTR":
+			return ValidateNAPTRRecord()(i, k)
 		}
 
 		return warnings, errors
@@ -216,6 +311,34 @@ func isValidHostname(hostname string) bool {
 	return true
 }
 
+// isValidDomainName checks if a string is a valid domain name; unlike
+// isValidHostname it accepts labels starting with an underscore
+func isValidDomainName(name string) bool {
+	labels := strings.Split(strings.TrimSuffix(name, "."), ".")
+	for i, label := range labels {
+		labels[i] = strings.TrimPrefix(label, "_")
+	}
+	return isValidHostname(strings.Join(labels, "."))
+}
+
+// isIPNetwork checks if a string is an IP address or CIDR block of the given family
+func isIPNetwork(value string, family int) bool {
+	ip := net.ParseIP(value)
+	if strings.Contains(value, "/") {
+		var err error
+		if ip, _, err = net.ParseCIDR(value); err != nil {
+			return false
+		}
+	}
+	if ip == nil {
+		return false
+	}
+	if family == net.IPv4len {
+		return ip.To4() != nil
+	}
+	return ip.To4() == nil
+}
+
 func isAlphaNum(ch byte) bool {
 	return (ch >= 'a' && ch <= 'z') || (ch >= 'A' && ch <= 'Z') || (ch >= '0' && ch <= '9')
 }
@@ -313,6 +313,10 @@ func TestValidateDNSRecordValue(t *testing.T) {
 		{"valid_srv_record", "SRV", "10 60 5060 sip.example.com", false},
 		{"invalid_srv_record", "SRV", "sip.example.com", true},
 		
+		// NAPTR records
+		{"valid_naptr_record", "NAPTR", `100 10 "S" "SIP+D2U" "" _sip._udp.example.com`, false},
+		{"invalid_naptr_record", "NAPTR", "100 10 S SIP+D2U", true},
+		
 		// Unknown type (no validation)
 		{"unknown_type", "UNKNOWN", "anything", false},
 		
@@ -436,4 +440,112 @@ func BenchmarkIsValidHostname(b *testing.B) {
 			_ = isValidHostname(hostname)
 		}
 	})
-}
\ No newline at end of file
+}
+
+func TestValidateIPNetworks(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		validator   func(interface{}, string) ([]string, []error)
+		input       interface{}
+		expectError bool
+	}{
+		{"ipv4_address", ValidateIPv4Network(), "192.0.2.1", false},
+		{"ipv4_cidr", ValidateIPv4Network(), "192.0.2.0/24", false},
+		{"ipv4_rejects_ipv6", ValidateIPv4Network(), "2001:db8::/32", true},
+		{"ipv4_bad_prefix", ValidateIPv4Network(), "192.0.2.0/33", true},
+		{"ipv4_non_string", ValidateIPv4Network(), 192, true},
+		{"ipv6_address", ValidateIPv6Network(), "2001:db8::1", false},
+		{"ipv6_cidr", ValidateIPv6Network(), "2001:db8::/32", false},
+		{"ipv6_rejects_ipv4", ValidateIPv6Network(), "192.0.2.0/24", true},
+		{"ipv6_garbage", ValidateIPv6Network(), "example.com", true},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			warnings, errors := tt.validator(tt.input, "test")
+
+			if tt.expectError {
+				assert.NotEmpty(t, errors, "Expected error for input: %v", tt.input)
+			} else {
+				assert.Empty(t, errors, "Expected no error for input: %v", tt.input)
+			}
+			assert.Empty(t, warnings, "No warnings expected")
+		})
+	}
+}
+
+func TestValidateDomainName(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		input       interface{}
+		expectError bool
+	}{
+		{"plain", "example.com", false},
+		{"underscore_label", "_spf.example.com", false},
+		{"dkim_selector", "s1._domainkey.example.com", false},
+		{"fqdn", "_dmarc.example.com.", false},
+		{"bare_underscore", "_.example.com", true},
+		{"empty", "", true},
+		{"spaces", "ex ample.com", true},
+		{"non_string", 1, true},
+	}
+
+	validator := ValidateDomainName()
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			_, errors := validator(tt.input, "test")
+
+			if tt.expectError {
+				assert.NotEmpty(t, errors, "Expected error for input: %v", tt.input)
+			} else {
+				assert.Empty(t, errors, "Expected no error for input: %v", tt.input)
+			}
+		})
+	}
+}
+
+func TestValidateEmailAddress(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		input       interface{}
+		expectError bool
+	}{
+		{"plain", "help@example.com", false},
+		{"plus", "help+tickets@mail.example.com", false},
+		{"no_at", "example.com", true},
+		{"empty_local_part", "@example.com", true},
+		{"bad_domain", "help@-example.com", true},
+		{"space", "he lp@example.com", true},
+		{"non_string", 1, true},
+	}
+
+	validator := ValidateEmailAddress()
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			_, errors := validator(tt.input, "test")
+
+			if tt.expectError {
+				assert.NotEmpty(t, errors, "Expected error for input: %v", tt.input)
+			} else {
+				assert.Empty(t, errors, "Expected no error for input: %v", tt.input)
+			}
+		})
+	}
+}
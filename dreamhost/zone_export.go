@@ -0,0 +1,169 @@
+package dreamhost
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	dreamhostapi "github.com/adamantal/go-dreamhost/api"
+	"github.com/pkg/errors"
+)
+
+const (
+	zoneExportBIND = "bind"
+	zoneExportJSON = "json"
+	zoneExportCSV  = "csv"
+
+	// defaultZoneTTL is the TTL DreamHost serves its records with
+	defaultZoneTTL = 14400
+)
+
+// zoneExportRecord is a record of an exported zone, with its name relative to the origin
+type zoneExportRecord struct {
+	Name     string `json:"name"`
+	FQDN     string `json:"fqdn"`
+	Type     string `json:"type"`
+	Value    string `json:"value"`
+	Comment  string `json:"comment,omitempty"`
+	Editable bool   `json:"editable"`
+}
+
+// zoneExportRecords selects the records of a zone and sorts them by relative
+// name (apex first), type and value
+func zoneExportRecords(records []dreamhostapi.DNSRecord, zone string) []zoneExportRecord {
+	zone = dnsRecordFQDN(zone)
+
+	var result []zoneExportRecord
+	for _, record := range records {
+		if dnsRecordFQDN(record.Zone) != zone {
+			continue
+		}
+		fqdn := dnsRecordFQDN(record.Record)
+		result = append(result, zoneExportRecord{
+			Name:     relativeRecordName(fqdn, zone),
+			FQDN:     fqdn,
+			Type:     string(record.Type),
+			Value:    record.Value,
+			Comment:  record.Comment,
+			Editable: record.Editable == dreamhostapi.Editable,
+		})
+	}
+
+	sort.SliceStable(result, func(i, j int) bool {
+		a, b := result[i], result[j]
+		if a.Name != b.Name {
+			// the apex sorts first
+			return a.Name == "@" || (b.Name != "@" && a.Name < b.Name)
+		}
+		if a.Type != b.Type {
+			return a.Type < b.Type
+		}
+		return a.Value < b.Value
+	})
+	return result
+}
+
+// relativeRecordName returns the name relative to the zone, "@" for the apex and
+// the absolute name with a trailing dot for names outside of the zone
+func relativeRecordName(fqdn, zone string) string {
+	switch {
+	case fqdn == zone:
+		return "@"
+	case strings.HasSuffix(fqdn, "."+zone):
+		return strings.TrimSuffix(fqdn, "."+zone)
+	default:
+		return dnsFQDN(fqdn)
+	}
+}
+
+// renderZoneExport renders the records in the given format
+func renderZoneExport(zone string, records []zoneExportRecord, format string, ttl int) (string, error) {
+	switch format {
+	case zoneExportBIND:
+		return renderBINDZone(zone, records, ttl), nil
+	case zoneExportJSON:
+		return renderJSONZone(zone, records)
+	case zoneExportCSV:
+		return renderCSVZone(records)
+	default:
+		return "", errors.Errorf("unsupported zone export format: %s", format)
+	}
+}
+
+// renderBINDZone renders an RFC 1035 zone file
+func renderBINDZone(zone string, records []zoneExportRecord, ttl int) string {
+	var buf strings.Builder
+
+	fmt.Fprintf(&buf, "$ORIGIN %s\n", dnsFQDN(dnsRecordFQDN(zone)))
+	fmt.Fprintf(&buf, "$TTL %d\n", ttl)
+
+	for _, record := range records {
+		line := fmt.Sprintf("%s\tIN\t%s\t%s", record.Name, record.Type, bindRecordData(record.Type, record.Value))
+		if record.Comment != "" {
+			line += "\t; " + strings.ReplaceAll(record.Comment, "\n", " ")
+		}
+		buf.WriteString(line + "\n")
+	}
+
+	return buf.String()
+}
+
+// bindRecordData renders a record value in zone file presentation format: TXT
+// values are quoted and split into character-strings, and domain names are made
+// absolute so they are not read relative to $ORIGIN
+func bindRecordData(recordType, value string) string {
+	switch recordType {
+	case string(dreamhostapi.TXTRecordType):
+		chunks := make([]string, 0, len(value)/maxTXTStringLength+1)
+		for len(value) > maxTXTStringLength {
+			chunks = append(chunks, quoteCharacterString(value[:maxTXTStringLength]))
+			value = value[maxTXTStringLength:]
+		}
+		chunks = append(chunks, quoteCharacterString(value))
+		return strings.Join(chunks, " ")
+	case string(dreamhostapi.CNAMERecordType), string(dreamhostapi.NSRecordType), string(ptrRecordType):
+		return dnsFQDN(value)
+	case "MX", string(dreamhostapi.SRVRecordType):
+		fields := strings.Fields(value)
+		if len(fields) > 0 {
+			fields[len(fields)-1] = dnsFQDN(fields[len(fields)-1])
+		}
+		return strings.Join(fields, " ")
+	default:
+		return value
+	}
+}
+
+func renderJSONZone(zone string, records []zoneExportRecord) (string, error) {
+	if records == nil {
+		records = []zoneExportRecord{}
+	}
+	document, err := json.MarshalIndent(struct {
+		Zone    string             `json:"zone"`
+		Records []zoneExportRecord `json:"records"`
+	}{dnsRecordFQDN(zone), records}, "", "  ")
+	if err != nil {
+		return "", errors.Wrap(err, "failed to render zone as JSON")
+	}
+	return string(document) + "\n", nil
+}
+
+func renderCSVZone(records []zoneExportRecord) (string, error) {
+	var buf bytes.Buffer
+
+	writer := csv.NewWriter(&buf)
+	rows := [][]string{{"name", "fqdn", "type", "value", "comment", "editable"}}
+	for _, record := range records {
+		rows = append(rows, []string{
+			record.Name, record.FQDN, record.Type, record.Value, record.Comment, fmt.Sprint(record.Editable),
+		})
+	}
+	if err := writer.WriteAll(rows); err != nil {
+		return "", errors.Wrap(err, "failed to render zone as CSV")
+	}
+
+	return buf.String(), nil
+}
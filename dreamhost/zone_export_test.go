@@ -0,0 +1,119 @@
+package dreamhost
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	dreamhostapi "github.com/adamantal/go-dreamhost/api"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testZoneExportRecords() []dreamhostapi.DNSRecord {
+	return []dreamhostapi.DNSRecord{
+		{Zone: "example.com", Record: "www.example.com", Type: dreamhostapi.CNAMERecordType, Value: "example.com", Editable: dreamhostapi.Editable},
+		{Zone: "example.com", Record: "example.com", Type: dreamhostapi.TXTRecordType, Value: `say "hi"`, Editable: dreamhostapi.Editable},
+		{Zone: "example.com", Record: "example.com", Type: "MX", Value: "10 mx.example.com", Editable: dreamhostapi.Editable},
+		{Zone: "example.com", Record: "example.com", Type: dreamhostapi.ARecordType, Value: "192.0.2.1",
+			Comment: "web, primary", Editable: dreamhostapi.Editable},
+		{Zone: "example.com", Record: "example.com", Type: dreamhostapi.NSRecordType, Value: "ns1.dreamhost.com", Editable: dreamhostapi.NotEditable},
+		{Zone: "example.com", Record: "_sip._udp.example.com", Type: dreamhostapi.SRVRecordType, Value: "0 5 5060 sip.example.com.", Editable: dreamhostapi.Editable},
+		{Zone: "example.org", Record: "example.org", Type: dreamhostapi.ARecordType, Value: "192.0.2.9", Editable: dreamhostapi.Editable},
+	}
+}
+
+func TestZoneExportRecords(t *testing.T) {
+	t.Parallel()
+
+	records := zoneExportRecords(testZoneExportRecords(), "Example.com.")
+	require.Len(t, records, 6)
+
+	var names []string
+	for _, record := range records {
+		names = append(names, record.Name+" "+record.Type)
+	}
+	assert.Equal(t, []string{"@ A", "@ MX", "@ NS", "@ TXT", "_sip._udp SRV", "www CNAME"}, names)
+
+	assert.Equal(t, "@", relativeRecordName("example.com", "example.com"))
+	assert.Equal(t, "a.b", relativeRecordName("a.b.example.com", "example.com"))
+	assert.Equal(t, "other.net.", relativeRecordName("other.net", "example.com"))
+}
+
+func TestRenderBINDZone(t *testing.T) {
+	t.Parallel()
+
+	records := zoneExportRecords(testZoneExportRecords(), "example.com")
+	content, err := renderZoneExport("example.com", records, zoneExportBIND, defaultZoneTTL)
+	require.NoError(t, err)
+
+	expected := "$ORIGIN example.com.\n" +
+		"$TTL 14400\n" +
+		"@\tIN\tA\t192.0.2.1\t; web, primary\n" +
+		"@\tIN\tMX\t10 mx.example.com.\n" +
+		"@\tIN\tNS\tns1.dreamhost.com.\n" +
+		"@\tIN\tTXT\t\"say \\\"hi\\\"\"\n" +
+		"_sip._udp\tIN\tSRV\t0 5 5060 sip.example.com.\n" +
+		"www\tIN\tCNAME\texample.com.\n"
+	assert.Equal(t, expected, content)
+}
+
+func TestBINDRecordDataLongTXT(t *testing.T) {
+	t.Parallel()
+
+	value := strings.Repeat("a", maxTXTStringLength) + "bc"
+	data := bindRecordData("TXT", value)
+	assert.Equal(t, `"`+strings.Repeat("a", maxTXTStringLength)+`" "bc"`, data)
+
+	fields, err := splitCharacterStrings(data)
+	require.NoError(t, err)
+	assert.Equal(t, value, strings.Join(fields, ""))
+}
+
+func TestRenderJSONAndCSVZone(t *testing.T) {
+	t.Parallel()
+
+	records := zoneExportRecords(testZoneExportRecords(), "example.com")
+
+	content, err := renderZoneExport("example.com", records[:1], zoneExportJSON, defaultZoneTTL)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"zone": "example.com", "records": [
+		{"name": "@", "fqdn": "example.com", "type": "A", "value": "192.0.2.1", "comment": "web, primary", "editable": true}
+	]}`, content)
+
+	content, err = renderZoneExport("example.com", records[:2], zoneExportCSV, defaultZoneTTL)
+	require.NoError(t, err)
+	assert.Equal(t, "name,fqdn,type,value,comment,editable\n"+
+		"@,example.com,A,192.0.2.1,\"web, primary\",true\n"+
+		"@,example.com,MX,10 mx.example.com,,true\n", content)
+
+	_, err = renderZoneExport("example.com", records, "yaml", defaultZoneTTL)
+	assert.Error(t, err)
+}
+
+func TestDataSourceDNSZoneExportReadID(t *testing.T) {
+	t.Parallel()
+
+	mock := NewMockDreamhostClient()
+	mock.SetRecords(testZoneExportRecords())
+	client := newDreamhostClient(mock)
+
+	read := func(format string) *schema.ResourceData {
+		d := schema.TestResourceDataRaw(t, dataSourceDNSZoneExport().Schema, map[string]interface{}{
+			"zone": "example.com", "format": format,
+		})
+		diags := dataSourceDNSZoneExportRead(context.Background(), d, client)
+		require.False(t, diags.HasError(), "%v", diags)
+		return d
+	}
+
+	// the ID is the hash of the content, so it is stable and changes with it
+	bind := read("bind")
+	content, _ := bind.Get("content").(string)
+	id, err := hashID(map[string]interface{}{"content": content})
+	require.NoError(t, err)
+	assert.Equal(t, id, bind.Id())
+	assert.Equal(t, bind.Id(), read("bind").Id())
+	assert.NotEqual(t, bind.Id(), read("json").Id())
+}